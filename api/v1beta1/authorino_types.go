@@ -17,7 +17,9 @@ limitations under the License.
 package v1beta1
 
 import (
+	k8sapps "k8s.io/api/apps/v1"
 	k8score "k8s.io/api/core/v1"
+	k8srbac "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -29,6 +31,46 @@ type ConditionType string
 const (
 	// ConditionReady specifies that the resource is ready
 	ConditionReady ConditionType = "Ready"
+	// ConditionGatewayAPIAttached specifies that the Gateway API object generated for the resource is accepted by its parents
+	ConditionGatewayAPIAttached ConditionType = "GatewayAPIAttached"
+	// ConditionConflict specifies that the watch scope of the resource intersects with the one of another resource
+	ConditionConflict ConditionType = "Conflict"
+	// ConditionManaged specifies that the resource is reconciled by the operator
+	ConditionManaged ConditionType = "Managed"
+	// ConditionDriftDetected specifies that fields of the resources managed for the resource were modified by other field managers
+	ConditionDriftDetected ConditionType = "DriftDetected"
+	// ConditionTracingConfigured specifies that the tracing exporter of the resource is configured with a valid endpoint
+	ConditionTracingConfigured ConditionType = "TracingConfigured"
+)
+
+const (
+	// ManagementStateManaged is the default management state, in which the operator reconciles the Authorino instance
+	ManagementStateManaged = "Managed"
+	// ManagementStateUnmanaged pauses the reconciliation of the Authorino instance, only its status is reported
+	ManagementStateUnmanaged = "Unmanaged"
+	// ManagementStateRemoved tears down the resources of the Authorino instance, keeping the CR
+	ManagementStateRemoved = "Removed"
+)
+
+const (
+	// KubernetesAuthEnabled grants the Authorino instance the permissions to create TokenReviews and SubjectAccessReviews
+	KubernetesAuthEnabled = "Enabled"
+	// KubernetesAuthDisabled denies the Authorino instance the permissions to create TokenReviews and SubjectAccessReviews
+	KubernetesAuthDisabled = "Disabled"
+	// KubernetesAuthAuto grants the Authorino instance the permissions to create TokenReviews and SubjectAccessReviews
+	// only while an AuthConfig in its watch scope uses them
+	KubernetesAuthAuto = "Auto"
+)
+
+const (
+	// CanaryPhaseProgressing is the phase of a canary waiting for its Deployment to become available
+	CanaryPhaseProgressing = "Progressing"
+	// CanaryPhaseSoaking is the phase of a canary whose Deployment is available, for the soak period
+	CanaryPhaseSoaking = "Soaking"
+	// CanaryPhasePromoted is the phase of a canary whose image was promoted to the Authorino Deployment
+	CanaryPhasePromoted = "Promoted"
+	// CanaryPhaseRolledBack is the phase of a canary whose Deployment failed and was removed
+	CanaryPhaseRolledBack = "RolledBack"
 )
 
 type Condition struct {
@@ -64,8 +106,6 @@ type AuthorinoSpec struct {
 	ImagePullPolicy          k8score.PullPolicy `json:"imagePullPolicy,omitempty"`
 	Replicas                 *int32             `json:"replicas,omitempty"`
 	Volumes                  VolumesSpec        `json:"volumes,omitempty"`
-	LogLevel                 string             `json:"logLevel,omitempty"`
-	LogMode                  string             `json:"logMode,omitempty"`
 	ClusterWide              bool               `json:"clusterWide,omitempty"`
 	Listener                 Listener           `json:"listener"`
 	OIDCServer               OIDCServer         `json:"oidcServer"`
@@ -76,6 +116,69 @@ type AuthorinoSpec struct {
 	Tracing                  Tracing            `json:"tracing,omitempty"`
 	Metrics                  Metrics            `json:"metrics,omitempty"`
 	Healthz                  Healthz            `json:"healthz,omitempty"`
+	Integration              Integration        `json:"integration,omitempty"`
+
+	// Log level of Authorino (debug, info, warn or error).
+	// Not validated, so instances created before the values were documented stay valid; other values are passed as is.
+	// +optional
+	LogLevel string `json:"logLevel,omitempty"`
+	// Log mode of Authorino (development or production).
+	// Not validated, so instances created before the values were documented stay valid; other values are passed as is.
+	// +optional
+	LogMode string `json:"logMode,omitempty"`
+	// Encoding, timestamps and sampling of the log entries of Authorino.
+	// Not passed to Authorino until a release has the flags to set them, as Authorino fails to start on unknown flags.
+	// +optional
+	Logging *Logging `json:"logging,omitempty"`
+
+	// Shards the AuthConfigs across the Authorino instances of a group.
+	// The operator assigns each AuthConfig a shard label and narrows the AuthConfig label selector of the instance to
+	// its own shard.
+	// +optional
+	Sharding *Sharding `json:"sharding,omitempty"`
+
+	// Management state of the Authorino instance.
+	// Unmanaged pauses the reconciliation of the instance, e.g. to hand-edit the Deployment during an incident, while
+	// its status is still reported. Removed tears down the resources managed by the operator, but keeps the CR.
+	// +kubebuilder:validation:Enum=Managed;Unmanaged;Removed
+	// +kubebuilder:default=Managed
+	// +optional
+	ManagementState string `json:"managementState,omitempty"`
+
+	// What to do with the fields of the resources managed by the operator (e.g. the Deployment and Services) that were
+	// modified by other field managers, e.g. by hand. Force overwrites them; Preserve leaves them as they are.
+	// Either way, the drift is reported in the DriftDetected condition and with a Warning event. The drift overwritten is
+	// reported for 10 minutes.
+	// +kubebuilder:validation:Enum=Force;Preserve
+	// +kubebuilder:default=Force
+	// +optional
+	DriftPolicy string `json:"driftPolicy,omitempty"`
+
+	// Rollout of changes to the Authorino Deployment.
+	// +optional
+	Rollout *Rollout `json:"rollout,omitempty"`
+
+	// Canary upgrade of the Authorino image.
+	// The operator runs a second Deployment with the canary image behind the same Services as the Authorino Deployment,
+	// promotes the image, setting it as the image of the instance, when the canary stays available for the soak period,
+	// and removes the canary when it fails.
+	// +optional
+	Canary *Canary `json:"canary,omitempty"`
+
+	// Spreads the pods of the Authorino instance across zones and nodes, prefers scheduling them onto different nodes,
+	// and limits voluntary disruptions (e.g. node drains) to one pod at a time with a PodDisruptionBudget.
+	// +optional
+	HighAvailability bool `json:"highAvailability,omitempty"`
+
+	// Permissions of Authorino: the ClusterRoles bound to the instance and extra rules.
+	// +optional
+	RBAC *RBAC `json:"rbac,omitempty"`
+
+	// Leader election of the Authorino replicas, which elect a leader to update the status of the AuthConfigs.
+	// The lease duration, renew deadline, retry period and lease lock name are not passed to Authorino until a release
+	// has the flags to set them, as Authorino fails to start on unknown flags.
+	// +optional
+	LeaderElection *LeaderElection `json:"leaderElection,omitempty"`
 }
 
 type Listener struct {
@@ -90,11 +193,50 @@ type Listener struct {
 	Timeout *int `json:"timeout,omitempty"`
 	// Maximum payload (request body) size for the auth service (HTTP interface), in bytes.
 	MaxHttpRequestBodySize *int `json:"maxHttpRequestBodySize,omitempty"`
+	// Kubernetes Service of the auth service.
+	// +optional
+	Service ServiceOptions `json:"service,omitempty"`
 }
 
+// +kubebuilder:validation:XValidation:rule="!has(self.expose) || !has(self.expose.kind) || self.expose.kind != 'Ingress' || (has(self.tls.enabled) && !self.tls.enabled)",message="an Ingress cannot route to the OIDC server with tls enabled, disable it or expose the OIDC server with a Route"
+// +kubebuilder:validation:XValidation:rule="!has(self.expose) || !has(self.expose.termination) || ((self.expose.termination == 'edge') == (has(self.tls.enabled) && !self.tls.enabled))",message="termination must be edge when tls is disabled for the OIDC server, and passthrough or reencrypt otherwise"
 type OIDCServer struct {
 	Port *int32 `json:"port,omitempty"`
 	Tls  Tls    `json:"tls"`
+	// Kubernetes Service of the OIDC server.
+	// +optional
+	Service ServiceOptions `json:"service,omitempty"`
+	// Exposes the OIDC server outside of the cluster with an Ingress or an OpenShift Route.
+	// +optional
+	Expose *OIDCServerExpose `json:"expose,omitempty"`
+}
+
+type OIDCServerExpose struct {
+	// Kind of object that exposes the OIDC server (Ingress or Route).
+	// Defaults to Route when the OpenShift Route API is available in the cluster, otherwise to Ingress.
+	// An Ingress routes plain HTTP only, so it requires TLS disabled for the OIDC server.
+	// +optional
+	// +kubebuilder:validation:Enum=Ingress;Route
+	Kind string `json:"kind,omitempty"`
+	// Public host name of the OIDC server. Generated by OpenShift for a Route when omitted.
+	// +optional
+	Host string `json:"host,omitempty"`
+	// Annotations of the Ingress or Route.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// Name of the IngressClass (Ingress only).
+	// +optional
+	IngressClassName *string `json:"ingressClassName,omitempty"`
+	// Secret with the TLS certificate of the public host (Ingress only).
+	// +optional
+	TlsSecretRef *k8score.LocalObjectReference `json:"tlsSecretRef,omitempty"`
+	// TLS termination of the Route (Route only).
+	// Defaults to passthrough when TLS is enabled for the OIDC server, otherwise to edge. Edge requires TLS disabled for
+	// the OIDC server, passthrough and reencrypt require it enabled. With reencrypt, the Route validates the
+	// certificate of the OIDC server with the ca.crt of its Secret, or else with the certificate itself.
+	// +optional
+	// +kubebuilder:validation:Enum=passthrough;reencrypt;edge
+	Termination string `json:"termination,omitempty"`
 }
 
 type Ports struct {
@@ -106,11 +248,203 @@ type Tracing struct {
 	Endpoint string            `json:"endpoint"`
 	Tags     map[string]string `json:"tags,omitempty"`
 	Insecure bool              `json:"insecure,omitempty"`
+	// TLS configuration of the connection to the tracing collector.
+	// Passed to Authorino in the env vars of the OpenTelemetry SDK (OTEL_EXPORTER_OTLP_TRACES_CERTIFICATE,
+	// OTEL_EXPORTER_OTLP_TRACES_CLIENT_CERTIFICATE and OTEL_EXPORTER_OTLP_TRACES_CLIENT_KEY).
+	// +optional
+	Tls *TracingTls `json:"tls,omitempty"`
+	// Sampling of the traces.
+	// Passed to Authorino in the env vars of the OpenTelemetry SDK (OTEL_TRACES_SAMPLER and OTEL_TRACES_SAMPLER_ARG).
+	// +optional
+	Sampling *TracingSampling `json:"sampling,omitempty"`
+	// Headers sent to the tracing collector (e.g. for authentication), with values read from Secrets.
+	// Passed to Authorino in the OTEL_EXPORTER_OTLP_TRACES_HEADERS env var, thus values with commas or percent signs
+	// must be percent-encoded.
+	// +optional
+	Headers []TracingHeader `json:"headers,omitempty"`
+}
+
+type TracingTls struct {
+	// Secret with the CA certificate (ca.crt) to verify the certificate of the tracing collector.
+	// +optional
+	CASecret *k8score.LocalObjectReference `json:"caSecret,omitempty"`
+	// Secret with the client certificate (tls.crt) and key (tls.key), for mTLS with the tracing collector.
+	// +optional
+	ClientCertSecret *k8score.LocalObjectReference `json:"clientCertSecret,omitempty"`
+}
+
+type TracingSampling struct {
+	// Ratio of the traces sampled, from 0 to 1 (e.g. "0.1").
+	// +kubebuilder:validation:Pattern=`^(0(\.[0-9]+)?|1(\.0+)?)$`
+	// +optional
+	Ratio string `json:"ratio,omitempty"`
+	// Whether to follow the sampling decision of the parent span, when there is one. Defaults to true.
+	// +optional
+	ParentBased *bool `json:"parentBased,omitempty"`
+}
+
+type TracingHeader struct {
+	// Name of the header.
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9!#$%&'*+.^_|~-]+$`
+	Name string `json:"name"`
+	// Key of the Secret with the value of the header.
+	ValueFrom k8score.SecretKeySelector `json:"valueFrom"`
+}
+
+type Logging struct {
+	// Encoding of the log entries. Defaults to json in production mode and to console in development mode.
+	// +kubebuilder:validation:Enum=json;console
+	// +optional
+	Encoder string `json:"encoder,omitempty"`
+	// Format of the timestamps of the log entries. Defaults to rfc3339.
+	// +kubebuilder:validation:Enum=epoch;millis;nanos;iso8601;rfc3339;rfc3339nano
+	// +optional
+	TimeFormat string `json:"timeFormat,omitempty"`
+	// Sampling of the log entries, to cap the CPU and I/O spent logging repeated entries.
+	// In production mode, entries are sampled anyway past the first 100 with the same level and message each second.
+	// +optional
+	Sampling *LogSampling `json:"sampling,omitempty"`
+}
+
+type LogSampling struct {
+	// Number of entries with the same level and message logged each second, before sampling.
+	// +kubebuilder:validation:Minimum=1
+	Initial int32 `json:"initial"`
+	// Past the initial entries, only every Nth entry with the same level and message is logged, within the second.
+	// +kubebuilder:validation:Minimum=1
+	Thereafter int32 `json:"thereafter"`
+}
+
+type RBAC struct {
+	// ClusterRole with the permissions of Authorino to watch AuthConfigs and Secrets.
+	// Must be one of the allowedClusterRoles of the operator configuration.
+	// Defaults to the one of the operator configuration, then to authorino-manager-role.
+	// +optional
+	ManagerClusterRole string `json:"managerClusterRole,omitempty"`
+	// ClusterRole with the permissions of Authorino to create TokenReviews and SubjectAccessReviews.
+	// Must be one of the allowedClusterRoles of the operator configuration.
+	// Defaults to the one of the operator configuration, then to authorino-manager-k8s-auth-role.
+	// +optional
+	K8sAuthClusterRole string `json:"k8sAuthClusterRole,omitempty"`
+	// Whether the k8s-auth ClusterRole is bound to the instance, for the kubernetesTokenReview authentication and the
+	// kubernetesSubjectAccessReview authorization of the AuthConfigs.
+	// Auto binds it only while an AuthConfig in the watch scope of the instance uses them.
+	// +kubebuilder:validation:Enum=Enabled;Disabled;Auto
+	// +kubebuilder:default=Enabled
+	// +optional
+	KubernetesAuth string `json:"kubernetesAuth,omitempty"`
+	// Extra permissions of Authorino, e.g. to read the resources looked up by the kubernetes metadata evaluators.
+	// Each rule must be covered by one of the allowedRules of the operator configuration.
+	// Granted within the watch scope of the instance, by a Role in its namespace, or by a ClusterRole for cluster-wide
+	// instances and instances with a namespace selector.
+	// +optional
+	Rules []k8srbac.PolicyRule `json:"rules,omitempty"`
+}
+
+// +kubebuilder:validation:XValidation:rule="!has(self.leaseDuration) || !has(self.renewDeadline) || duration(self.renewDeadline) < duration(self.leaseDuration)",message="renewDeadline must be less than leaseDuration"
+type LeaderElection struct {
+	// Whether the Authorino replicas elect a leader.
+	// Defaults to enabled with more than one replica or a canary. Set it to true when a HorizontalPodAutoscaler may
+	// scale the instance above one replica.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// Duration the replicas that are not the leader wait before taking over the leadership, e.g. 15s.
+	// +optional
+	LeaseDuration *metav1.Duration `json:"leaseDuration,omitempty"`
+	// Duration the leader retries to renew the leadership before giving it up, e.g. 10s.
+	// +optional
+	RenewDeadline *metav1.Duration `json:"renewDeadline,omitempty"`
+	// Duration the replicas wait between tries to acquire or renew the leadership, e.g. 2s.
+	// +optional
+	RetryPeriod *metav1.Duration `json:"retryPeriod,omitempty"`
+	// Name of the Lease used as lock, in the namespace of the instance.
+	// Defaults to the one of Authorino, shared by all the Authorino instances of the namespace.
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`
+	// +optional
+	LeaseLockName string `json:"leaseLockName,omitempty"`
 }
 
 type Metrics struct {
 	Port               *int32 `json:"port,omitempty"`
 	DeepMetricsEnabled *bool  `json:"deep,omitempty"`
+	// Kubernetes Service of the metrics server.
+	// +optional
+	Service ServiceOptions `json:"service,omitempty"`
+}
+
+type ServiceOptions struct {
+	// Whether the operator creates the Service. When disabled, an existing Service is deleted; the server keeps running
+	// in the Authorino pods.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// +kubebuilder:validation:XValidation:rule="self.index < self.shards",message="index must be lower than shards"
+type Sharding struct {
+	// Name of the group of Authorino instances the AuthConfigs are sharded across.
+	// All the instances of a group must set the same number of shards and strategy.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=63
+	Group string `json:"group"`
+	// Total number of shards of the group.
+	// +kubebuilder:validation:Minimum=1
+	Shards int32 `json:"shards"`
+	// Shard of the group served by this Authorino instance, from 0 to shards-1.
+	// +kubebuilder:validation:Minimum=0
+	Index int32 `json:"index"`
+	// How AuthConfigs are assigned to shards: Hash (of the namespace and name of the AuthConfig) or Namespace (hash of
+	// the namespace only, so all the AuthConfigs of a namespace are served by the same shard).
+	// +kubebuilder:validation:Enum=Hash;Namespace
+	// +kubebuilder:default=Hash
+	// +optional
+	Strategy string `json:"strategy,omitempty"`
+}
+
+// +kubebuilder:validation:XValidation:rule="!has(self.progressDeadlineSeconds) || !has(self.minReadySeconds) || self.progressDeadlineSeconds > self.minReadySeconds",message="progressDeadlineSeconds must be greater than minReadySeconds"
+type Rollout struct {
+	// Rolling update of the pods of the Authorino Deployment, i.e. the maximum number of pods above the desired number of
+	// replicas (maxSurge) and of unavailable pods (maxUnavailable) during a rollout.
+	// +optional
+	RollingUpdate *k8sapps.RollingUpdateDeployment `json:"rollingUpdate,omitempty"`
+	// Minimum number of seconds a new Authorino pod must be ready, without any of its containers crashing, to be
+	// considered available.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinReadySeconds *int32 `json:"minReadySeconds,omitempty"`
+	// Maximum number of seconds for a rollout to make progress before it is considered failed and reported with the
+	// RolloutFailed reason.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ProgressDeadlineSeconds *int32 `json:"progressDeadlineSeconds,omitempty"`
+	// Number of seconds for a new revision of the Authorino Deployment to become available before the operator rolls
+	// back to the pod template of the last revision that did. Automatic rollback is disabled when unset.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RollbackAfterSeconds *int32 `json:"rollbackAfterSeconds,omitempty"`
+}
+
+type Canary struct {
+	// Authorino image run by the canary Deployment.
+	// +kubebuilder:validation:MinLength=1
+	Image string `json:"image"`
+	// Number of replicas of the canary Deployment, in percentage of the replicas of the Authorino instance, rounded up.
+	// The Authorino Deployment keeps its replicas, and the traffic is balanced across the pods of both Deployments.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:default=10
+	// +optional
+	Weight int32 `json:"weight,omitempty"`
+	// Number of seconds the canary Deployment must stay available before the canary image is promoted.
+	// Defaults to 300.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	SoakSeconds *int32 `json:"soakSeconds,omitempty"`
+	// Maximum number of seconds for the canary Deployment to become available before the canary is rolled back.
+	// Defaults to 600.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
 }
 
 type Healthz struct {
@@ -118,6 +452,69 @@ type Healthz struct {
 	Port *int32 `json:"port,omitempty"`
 }
 
+type Integration struct {
+	// Attachment of the auth service to the Gateway API.
+	// +optional
+	GatewayAPI *GatewayAPIIntegration `json:"gatewayAPI,omitempty"`
+	// Registration of the auth service as an Istio extension provider.
+	// +optional
+	Istio *IstioIntegration `json:"istio,omitempty"`
+}
+
+// The extension provider is named <namespace>.<name>-authorino and registered in the mesh config ConfigMap set in the
+// operator configuration
+type IstioIntegration struct {
+	// Generates an AuthorizationPolicy with CUSTOM action that delegates authorization to the extension provider.
+	// +optional
+	AuthorizationPolicy *IstioAuthorizationPolicy `json:"authorizationPolicy,omitempty"`
+}
+
+type NamespacedObjectReference struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+type IstioAuthorizationPolicy struct {
+	// Labels of the workloads in the namespace of the Authorino instance the AuthorizationPolicy applies to.
+	// +optional
+	Selector map[string]string `json:"selector,omitempty"`
+}
+
+type GatewayAPIIntegration struct {
+	// Kind of object generated to attach the auth service: GRPCRoute, HTTPRoute, or an Envoy Gateway SecurityPolicy
+	// that references the auth service as ext_authz backend.
+	// +kubebuilder:validation:Enum=GRPCRoute;HTTPRoute;SecurityPolicy
+	Kind string `json:"kind"`
+	// Gateways the route attaches to (GRPCRoute and HTTPRoute only).
+	// +optional
+	ParentRefs []GatewayAPIReference `json:"parentRefs,omitempty"`
+	// Host names of the route (GRPCRoute and HTTPRoute only).
+	// +optional
+	Hostnames []string `json:"hostnames,omitempty"`
+	// Gateway API resources the SecurityPolicy applies to, in the namespace of the Authorino instance (SecurityPolicy only).
+	// +optional
+	TargetRefs []GatewayAPIReference `json:"targetRefs,omitempty"`
+}
+
+type GatewayAPIReference struct {
+	// API group of the referent.
+	// +optional
+	// +kubebuilder:default=gateway.networking.k8s.io
+	Group string `json:"group,omitempty"`
+	// Kind of the referent.
+	// +optional
+	// +kubebuilder:default=Gateway
+	Kind string `json:"kind,omitempty"`
+	// Name of the referent.
+	Name string `json:"name"`
+	// Namespace of the referent. Defaults to the namespace of the Authorino instance (parentRefs only).
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Name of a section within the referent, e.g. a Gateway listener.
+	// +optional
+	SectionName string `json:"sectionName,omitempty"`
+}
+
 type Tls struct {
 	Enabled    *bool                         `json:"enabled,omitempty"`
 	CertSecret *k8score.LocalObjectReference `json:"certSecretRef,omitempty"`
@@ -163,13 +560,94 @@ type AuthorinoStatus struct {
 	// Important: Run "make" to regenerate code after modifying this file
 
 	// Conditions is an array of the current Authorino's CR conditions
-	// Supported condition types: ConditionReady
+	// Supported condition types: ConditionReady, ConditionGatewayAPIAttached, ConditionConflict, ConditionManaged,
+	// ConditionDriftDetected, ConditionTracingConfigured
 	// +optional
 	// +patchMergeKey=type
 	// +patchStrategy=merge
 	// +listType=map
 	// +listMapKey=type
 	Conditions []Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+
+	// Public URL of the OIDC server, when exposed outside of the cluster
+	// +optional
+	OIDCServerURL string `json:"oidcServerUrl,omitempty"`
+
+	// Extension provider registered in the Istio mesh config for the auth service
+	// +optional
+	IstioExtensionProvider *IstioExtensionProviderStatus `json:"istioExtensionProvider,omitempty"`
+
+	// Number of AuthConfigs assigned to each shard of the sharding group
+	// +optional
+	Shards []ShardStatus `json:"shards,omitempty"`
+
+	// Readiness of the AuthConfigs in the watch scope of the Authorino instance
+	// +optional
+	AuthConfigs *AuthConfigsStatus `json:"authConfigs,omitempty"`
+
+	// Progress of the canary upgrade of the Authorino image
+	// +optional
+	Canary *CanaryStatus `json:"canary,omitempty"`
+
+	// Revisions of the Authorino Deployment, when automatic rollback is enabled
+	// +optional
+	Rollout *RolloutStatus `json:"rollout,omitempty"`
+}
+
+type RolloutStatus struct {
+	// Revision of the Authorino Deployment desired, i.e. hash of its pod template
+	Revision string `json:"revision"`
+	// Time the desired revision was first applied
+	RevisionTime metav1.Time `json:"revisionTime"`
+	// Last revision that became available
+	// +optional
+	LastKnownGoodRevision string `json:"lastKnownGoodRevision,omitempty"`
+	// ReplicaSet of the last revision that became available, kept by the Deployment controller in the revision history,
+	// whose pod template is re-applied when a later revision fails
+	// +optional
+	LastKnownGoodReplicaSet string `json:"lastKnownGoodReplicaSet,omitempty"`
+	// Last revision rolled back for not becoming available
+	// +optional
+	FailedRevision string `json:"failedRevision,omitempty"`
+	// Why the failed revision was rolled back
+	// +optional
+	FailureReason string `json:"failureReason,omitempty"`
+}
+
+type CanaryStatus struct {
+	// Authorino image run by the canary
+	Image string `json:"image"`
+	// Phase of the canary: Progressing, Soaking, Promoted or RolledBack
+	Phase string `json:"phase"`
+	// Last time the canary transitioned from one phase to another
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+	// Human readable message indicating details about the phase
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+type AuthConfigsStatus struct {
+	// Number of AuthConfigs in the watch scope of the Authorino instance
+	Total int32 `json:"total"`
+	// Number of AuthConfigs ready
+	Ready int32 `json:"ready"`
+	// Namespaced names of the AuthConfigs not ready (up to 10)
+	// +optional
+	NotReady []string `json:"notReady,omitempty"`
+}
+
+type ShardStatus struct {
+	// Index of the shard
+	Index int32 `json:"index"`
+	// Number of AuthConfigs assigned to the shard
+	AuthConfigs int32 `json:"authConfigs"`
+}
+
+type IstioExtensionProviderStatus struct {
+	// Name of the extension provider
+	Name string `json:"name"`
+	// Reference to the ConfigMap with the Istio mesh config
+	MeshConfigMap NamespacedObjectReference `json:"meshConfigMap"`
 }
 
 func (status *AuthorinoStatus) Ready() bool {
@@ -185,6 +663,11 @@ func (status *AuthorinoStatus) Ready() bool {
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:resource:path="authorinos"
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="AuthConfigs Ready",type=integer,JSONPath=`.status.authConfigs.ready`
+//+kubebuilder:printcolumn:name="AuthConfigs Total",type=integer,JSONPath=`.status.authConfigs.total`
+//+kubebuilder:printcolumn:name="Canary",type=string,JSONPath=`.status.canary.phase`,priority=1
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // Authorino is the Schema for the authorinos API
 type Authorino struct {
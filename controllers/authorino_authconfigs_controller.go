@@ -0,0 +1,80 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	api "github.com/kuadrant/authorino-operator/api/v1beta1"
+	"github.com/kuadrant/authorino-operator/pkg/config"
+	"github.com/kuadrant/authorino-operator/pkg/reconcilers"
+	authorinoResources "github.com/kuadrant/authorino-operator/pkg/resources"
+)
+
+// AuthorinoAuthConfigsReconciler reconciles what depends on the AuthConfigs watched by an Authorino instance, i.e. the
+// sharding, the permissions for kubernetes auth and the AuthConfigs status, on the events of the AuthConfigs, without
+// the full reconciliation of the instance
+type AuthorinoAuthConfigsReconciler struct {
+	*reconcilers.AuthorinoReconciler
+}
+
+func (r *AuthorinoAuthConfigsReconciler) Reconcile(eventCtx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := r.Log.WithValues("authorino", req.NamespacedName)
+	logger.V(1).Info("Reconciling the AuthConfigs of authorino")
+	ctx := reconcilers.WithWatchedAuthConfigs(logr.NewContext(eventCtx, logger))
+
+	authorinoInstance := &api.Authorino{}
+	if err := r.Get(ctx, req.NamespacedName, authorinoInstance); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// left to the full reconciliation of the instance
+	if authorinoInstance.DeletionTimestamp != nil || !controllerutil.ContainsFinalizer(authorinoInstance, authorinoFinalizer) || authorinoInstance.Spec.ManagementState == api.ManagementStateRemoved {
+		return ctrl.Result{}, nil
+	}
+
+	// only the status is reported while the reconciliation is paused
+	if authorinoInstance.Spec.ManagementState != api.ManagementStateUnmanaged {
+		if err := r.ReconcileAuthorinoSharding(ctx, authorinoInstance); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		if err := r.ReconcileAuthorinoKubernetesAuthPermissions(ctx, authorinoInstance); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{}, r.ReconcileAuthorinoAuthConfigsStatus(ctx, authorinoInstance)
+}
+
+// SetupWithManager sets up the controller with the Manager, if the AuthConfig API is available in the cluster
+func (r *AuthorinoAuthConfigsReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if _, err := mgr.GetRESTMapper().RESTMapping(authorinoResources.AuthConfigGVK.GroupKind(), authorinoResources.AuthConfigGVK.Version); err != nil {
+		return nil
+	}
+
+	// AuthConfigs added, removed, relabeled or whose readiness changes must be assigned to a shard and counted by the
+	// instances that watch them, which may also need the permissions for kubernetes auth
+	authConfig := &unstructured.Unstructured{}
+	authConfig.SetGroupVersionKind(authorinoResources.AuthConfigGVK)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("authorino-authconfigs").
+		WithOptions(controller.Options{MaxConcurrentReconciles: config.Current().MaxConcurrentReconciles}).
+		Watches(authConfig, handler.EnqueueRequestsFromMapFunc(r.authorinosWatching)).
+		Complete(r)
+}
+
+// authorinosWatching maps an event of an AuthConfig to the Authorino instances whose watch scope includes the AuthConfig
+func (r *AuthorinoAuthConfigsReconciler) authorinosWatching(ctx context.Context, obj client.Object) []reconcile.Request {
+	return authorinosMatching(r.Client, r.Log, func(authorino *api.Authorino) bool {
+		return reconcilers.AuthConfigInScope(authorino, obj)
+	})(ctx, obj)
+}
@@ -19,16 +19,28 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/go-logr/logr"
 	k8sapps "k8s.io/api/apps/v1"
 	k8score "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlbuilder "sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	api "github.com/kuadrant/authorino-operator/api/v1beta1"
+	"github.com/kuadrant/authorino-operator/pkg/config"
 	"github.com/kuadrant/authorino-operator/pkg/reconcilers"
 	authorinoResources "github.com/kuadrant/authorino-operator/pkg/resources"
 )
@@ -36,6 +48,10 @@ import (
 // AuthorinoReconciler reconciles a Authorino object
 type AuthorinoReconciler struct {
 	*reconcilers.AuthorinoReconciler
+
+	// ConfigChanged triggers the reconciliation of all the Authorino instances, e.g. when the operator configuration
+	// is reloaded
+	ConfigChanged <-chan event.GenericEvent
 }
 
 //+kubebuilder:rbac:groups=operator.authorino.kuadrant.io,resources=authorinos,verbs=get;list;watch;create;update;patch;delete
@@ -43,10 +59,18 @@ type AuthorinoReconciler struct {
 //+kubebuilder:rbac:groups=operator.authorino.kuadrant.io,resources=authorinos/finalizers,verbs=update
 
 // +kubebuilder:rbac:groups="apps",resources=deployments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="apps",resources=replicasets,verbs=get;list;watch;delete
 // +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=clusterroles,verbs=get;list;watch;create;update;
+// +kubebuilder:rbac:groups="networking.k8s.io",resources=ingresses,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="route.openshift.io",resources=routes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="route.openshift.io",resources=routes/custom-host,verbs=create
+// +kubebuilder:rbac:groups="gateway.networking.k8s.io",resources=grpcroutes;httproutes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="gateway.envoyproxy.io",resources=securitypolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="security.istio.io",resources=authorizationpolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=clusterroles,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=rolebindings,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=clusterrolebindings,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="policy",resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=roles,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="authentication.k8s.io",resources=tokenreviews,verbs=create;
@@ -54,6 +78,7 @@ type AuthorinoReconciler struct {
 // +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=configmaps/status,verbs=get;update;delete;patch
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch;
+// +kubebuilder:rbac:groups="events.k8s.io",resources=events,verbs=create;patch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 // +kubebuilder:rbac:groups="authorino.kuadrant.io",resources=authconfigs,verbs=create;delete;get;list;patch;update;watch
 // +kubebuilder:rbac:groups="authorino.kuadrant.io",resources=authconfigs/status,verbs=get;patch;update
@@ -86,6 +111,7 @@ func (r *AuthorinoReconciler) Reconcile(eventCtx context.Context, req ctrl.Reque
 	// authorino has been marked for deletion
 	if authorinoInstance.GetDeletionTimestamp() != nil && controllerutil.ContainsFinalizer(authorinoInstance, authorinoFinalizer) {
 		r.cleanupClusterScopedPermissions(ctx, req.NamespacedName, authorinoInstance.Labels)
+		r.CleanupIstioExtensionProvider(ctx, authorinoInstance)
 
 		controllerutil.RemoveFinalizer(authorinoInstance, authorinoFinalizer)
 		err = r.Client.Update(ctx, authorinoInstance)
@@ -111,6 +137,23 @@ func (r *AuthorinoReconciler) Reconcile(eventCtx context.Context, req ctrl.Reque
 		return ctrl.Result{}, nil
 	}
 
+	if err := r.ReconcileAuthorinoManagementState(ctx, authorinoInstance); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	switch authorinoInstance.Spec.ManagementState {
+	case api.ManagementStateUnmanaged:
+		// reconciliation paused (e.g. to hand-edit the Deployment during an incident), only the status is reported
+		return ctrl.Result{}, r.reportUnmanagedStatus(reconcilers.WithWatchedAuthConfigs(ctx), authorinoInstance)
+	case api.ManagementStateRemoved:
+		r.cleanupClusterScopedPermissions(ctx, req.NamespacedName, authorinoInstance.Labels)
+		return ctrl.Result{}, r.RemoveAuthorinoManagedResources(ctx, authorinoInstance)
+	}
+
+	// fields of the resources modified by other field managers are reported at the end of the reconciliation
+	ctx = reconcilers.WithDriftReport(ctx, authorinoInstance)
+	ctx = reconcilers.WithWatchedAuthConfigs(ctx)
+
 	if err := r.installationPreflightCheck(authorinoInstance); err != nil {
 		return ctrl.Result{Requeue: true}, err
 	}
@@ -119,6 +162,18 @@ func (r *AuthorinoReconciler) Reconcile(eventCtx context.Context, req ctrl.Reque
 		return ctrl.Result{}, err
 	}
 
+	if err := r.ReconcileAuthorinoOIDCServerExposure(ctx, authorinoInstance); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.ReconcileAuthorinoGatewayAPIIntegration(ctx, authorinoInstance); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.ReconcileAuthorinoIstioIntegration(ctx, authorinoInstance); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	if err := r.ReconcileAuthorinoServiceAccount(ctx, authorinoInstance); err != nil {
 		return ctrl.Result{}, err
 	}
@@ -127,19 +182,117 @@ func (r *AuthorinoReconciler) Reconcile(eventCtx context.Context, req ctrl.Reque
 		return ctrl.Result{}, err
 	}
 
+	if err := r.ReconcileAuthorinoSharding(ctx, authorinoInstance); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.ReconcileAuthorinoScopeOverlap(ctx, authorinoInstance); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.ReconcileAuthorinoAuthConfigsStatus(ctx, authorinoInstance); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.ReconcileAuthorinoTracingStatus(ctx, authorinoInstance); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.ReconcileAuthorinoPodDisruptionBudget(ctx, authorinoInstance); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// the canary goes first, so a canary image promoted is rolled out to the Authorino Deployment right away
+	if err := r.ReconcileAuthorinoCanary(ctx, authorinoInstance); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	if err := r.ReconcileAuthorinoDeployment(ctx, authorinoInstance); err != nil {
 		return ctrl.Result{}, err
 	}
 
-	return ctrl.Result{}, nil
+	if err := r.ReconcileAuthorinoDriftStatus(ctx, authorinoInstance); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: reconcilers.RequeueAfter(authorinoInstance)}, nil
+}
+
+// reportUnmanagedStatus reports the status of an Authorino instance whose resources are not reconciled, i.e. the steps
+// of the reconciliation that do not change any resource other than the status of the Authorino CR
+func (r *AuthorinoReconciler) reportUnmanagedStatus(ctx context.Context, authorinoInstance *api.Authorino) error {
+	if err := r.ReportAuthorinoDeploymentStatus(ctx, authorinoInstance); err != nil {
+		return err
+	}
+
+	if err := r.ReconcileAuthorinoScopeOverlap(ctx, authorinoInstance); err != nil {
+		return err
+	}
+
+	return r.ReconcileAuthorinoAuthConfigsStatus(ctx, authorinoInstance)
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *AuthorinoReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	builder := ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controller.Options{MaxConcurrentReconciles: config.Current().MaxConcurrentReconciles}).
 		Owns(&k8sapps.Deployment{}).
-		For(&api.Authorino{}).
-		Complete(r)
+		For(&api.Authorino{})
+
+	// the operator configuration applies to all the instances
+	if r.ConfigChanged != nil {
+		builder = builder.WatchesRawSource(source.Channel(r.ConfigChanged, handler.EnqueueRequestsFromMapFunc(authorinosMatching(r.Client, r.Log, func(*api.Authorino) bool { return true }))))
+	}
+
+	// changes to the watch scope of an instance may cause or resolve overlaps with the other instances
+	builder = builder.Watches(&api.Authorino{}, handler.EnqueueRequestsFromMapFunc(r.otherAuthorinos), ctrlbuilder.WithPredicates(predicate.GenerationChangedPredicate{}))
+
+	// watch the owned objects of optional APIs (OpenShift, Gateway API, Istio), so their status is reported back, only when
+	// the APIs are installed in the cluster
+	for _, gvk := range []schema.GroupVersionKind{
+		authorinoResources.RouteGVK,
+		authorinoResources.GRPCRouteGVK,
+		authorinoResources.HTTPRouteGVK,
+		authorinoResources.SecurityPolicyGVK,
+		authorinoResources.AuthorizationPolicyGVK,
+	} {
+		if _, err := mgr.GetRESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version); err != nil {
+			continue
+		}
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(gvk)
+		builder = builder.Owns(obj)
+	}
+
+	return builder.Complete(r)
+}
+
+// authorinosMatching maps any event to the Authorino instances that match the filter
+func authorinosMatching(c client.Client, logger logr.Logger, filter func(*api.Authorino) bool) handler.MapFunc {
+	return func(ctx context.Context, _ client.Object) []reconcile.Request {
+		authorinoList := &api.AuthorinoList{}
+		if err := c.List(ctx, authorinoList); err != nil {
+			logger.Error(err, "failed to list Authorino instances")
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for i := range authorinoList.Items {
+			authorino := &authorinoList.Items[i]
+			if filter(authorino) {
+				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(authorino)})
+			}
+		}
+		return requests
+	}
+}
+
+// otherAuthorinos maps an event of an Authorino instance to all the other Authorino instances
+func (r *AuthorinoReconciler) otherAuthorinos(ctx context.Context, obj client.Object) []reconcile.Request {
+	key := client.ObjectKeyFromObject(obj)
+	return authorinosMatching(r.Client, r.Log, func(authorino *api.Authorino) bool {
+		return client.ObjectKeyFromObject(authorino) != key
+	})(ctx, obj)
 }
 
 // TODO: this method should return error
@@ -151,25 +304,46 @@ func (r *AuthorinoReconciler) cleanupClusterScopedPermissions(ctx context.Contex
 	// namespaced ones are garbage collected automatically by k8s because of the owner reference
 
 	// Delete instance-specific ClusterRoleBindings
-	managerBinding := authorinoResources.GetAuthorinoClusterRoleBinding(crNamespacedName.Namespace, crName, reconcilers.AuthorinoManagerClusterRoleBindingName, reconcilers.AuthorinoManagerClusterRoleName, sa, labels)
+	managerBinding := authorinoResources.GetAuthorinoClusterRoleBinding(crNamespacedName.Namespace, crName, reconcilers.AuthorinoManagerClusterRoleBindingName, reconcilers.ManagerClusterRoleName(nil), sa, labels)
 	if err := r.Client.Delete(ctx, managerBinding); err != nil && !errors.IsNotFound(err) {
 		r.Log.Error(err, "failed to delete ClusterRoleBinding", "name", managerBinding.Name)
 	}
 
-	k8sAuthBinding := authorinoResources.GetAuthorinoClusterRoleBinding(crNamespacedName.Namespace, crName, reconcilers.AuthorinoK8sAuthClusterRoleBindingName, reconcilers.AuthorinoK8sAuthClusterRoleName, sa, labels)
+	k8sAuthBinding := authorinoResources.GetAuthorinoClusterRoleBinding(crNamespacedName.Namespace, crName, reconcilers.AuthorinoK8sAuthClusterRoleBindingName, reconcilers.K8sAuthClusterRoleName(nil), sa, labels)
 	if err := r.Client.Delete(ctx, k8sAuthBinding); err != nil && !errors.IsNotFound(err) {
 		r.Log.Error(err, "failed to delete ClusterRoleBinding", "name", k8sAuthBinding.Name)
 	}
+
+	// ClusterRole with the extra permissions of the instance, and its ClusterRoleBinding
+	rulesBinding := authorinoResources.GetAuthorinoClusterRoleBinding(crNamespacedName.Namespace, crName, reconcilers.AuthorinoRBACRulesRoleName, "", sa, labels)
+	if err := r.Client.Delete(ctx, rulesBinding); err != nil && !errors.IsNotFound(err) {
+		r.Log.Error(err, "failed to delete ClusterRoleBinding", "name", rulesBinding.Name)
+	}
+
+	rulesClusterRole := authorinoResources.GetAuthorinoClusterRole(crNamespacedName.Namespace, crName, reconcilers.AuthorinoRBACRulesRoleName, nil, labels)
+	if err := r.Client.Delete(ctx, rulesClusterRole); err != nil && !errors.IsNotFound(err) {
+		r.Log.Error(err, "failed to delete ClusterRole", "name", rulesClusterRole.Name)
+	}
 }
 
 func (r *AuthorinoReconciler) installationPreflightCheck(authorino *api.Authorino) error {
 
+	// the watch scope of the instance must be allowed by the operator configuration
+	if scope := reconcilers.WatchScope(authorino); !config.Current().ScopeAllowed(scope) {
+		return r.WrapErrorWithStatusUpdate(
+			r.Log, authorino, r.SetStatusFailed(statusScopeNotAllowed),
+			fmt.Errorf("%s watch scope not allowed by the operator configuration (allowed: %s)", scope, strings.Join(config.Current().AllowedScopes, ", ")),
+		)
+	}
+
 	// When tls is enabled, checks if the secret with the certs exists
 	// if not, installation of the authorino instance won't progress until the
 	// secret is created
 	tlsCerts := map[string]api.Tls{
 		"listener": authorino.Spec.Listener.Tls,
-		"oidc":     authorino.Spec.OIDCServer.Tls,
+	}
+	if reconcilers.OIDCServerEnabled(authorino) {
+		tlsCerts["oidc"] = authorino.Spec.OIDCServer.Tls
 	}
 
 	for authServerName, tlsCert := range tlsCerts {
@@ -138,9 +138,9 @@ var _ = Describe("Authorino controller", func() {
 				return k8sClient.Get(ctx, k8sAuthBindingNsdName, k8sAuthBinding)
 			}).WithContext(ctx).Should(Succeed())
 
-			// Authorino leaderElection ClusterRoleBinding
+			// Authorino leaderElection Role
 			leaderElectionRole := &k8srbac.Role{}
-			leaderElectionNsdName := namespacedName(testAuthorinoNamespace, reconcilers.AuthorinoLeaderElectionRoleName)
+			leaderElectionNsdName := namespacedName(testAuthorinoNamespace, authorinoInstance.Name+"-"+reconcilers.AuthorinoLeaderElectionRoleNameSuffix)
 			Eventually(func(ctx context.Context) error {
 				return k8sClient.Get(ctx, leaderElectionNsdName, leaderElectionRole)
 			}).WithContext(ctx).Should(Succeed())
@@ -395,9 +395,9 @@ var _ = Describe("Authorino controller", func() {
 				g.Expect(k8sClient.Get(ctx, k8sAuthBindingNsdName, k8sAuthBinding)).ToNot(HaveOccurred())
 			}).WithContext(ctx).Should(Succeed())
 
-			// Authorino leaderElection ClusterRoleBinding
+			// Authorino leaderElection Role
 			leaderElectionRole := &k8srbac.Role{}
-			leaderElectionNsdName := namespacedName(testAuthorinoNamespace, reconcilers.AuthorinoLeaderElectionRoleName)
+			leaderElectionNsdName := namespacedName(testAuthorinoNamespace, authorinoInstance.Name+"-"+reconcilers.AuthorinoLeaderElectionRoleNameSuffix)
 			Eventually(func(ctx context.Context) error {
 				return k8sClient.Get(ctx, leaderElectionNsdName, leaderElectionRole)
 			}).WithContext(ctx).Should(Succeed())
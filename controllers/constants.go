@@ -2,5 +2,6 @@ package controllers
 
 const (
 	statusTlsSecretNotProvided = "TlsSecretNotProvided"
+	statusScopeNotAllowed      = "ScopeNotAllowed"
 	authorinoFinalizer         = "authorino.kuadrant.io/finalizer"
 )
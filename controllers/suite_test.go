@@ -97,7 +97,12 @@ var _ = BeforeSuite(func() {
 	}
 
 	err = (&AuthorinoReconciler{
-		authorinoReconciler,
+		AuthorinoReconciler: authorinoReconciler,
+	}).SetupWithManager(mgr)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&AuthorinoAuthConfigsReconciler{
+		AuthorinoReconciler: authorinoReconciler,
 	}).SetupWithManager(mgr)
 	Expect(err).ToNot(HaveOccurred())
 
@@ -19,12 +19,14 @@ package main
 import (
 	"flag"
 	"fmt"
+	"net/http"
 	"os"
 	"runtime"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
+	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
@@ -32,9 +34,12 @@ import (
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
+	"github.com/kuadrant/authorino-operator/pkg/config"
 	"github.com/kuadrant/authorino-operator/pkg/log"
 	"github.com/kuadrant/authorino-operator/pkg/reconcilers"
 
@@ -60,41 +65,92 @@ func init() {
 }
 
 type logOptions struct {
-	level string
-	mode  string
+	level              string
+	mode               string
+	encoder            string
+	timeFormat         string
+	samplingInitial    int
+	samplingThereafter int
 }
 
-func setupLogger(opts logOptions) {
-	logOpts := log.Options{Level: log.ToLogLevel(opts.level), Mode: log.ToLogMode(opts.mode)}
+func setupLogger(opts logOptions) error {
+	level, err := log.ToLogLevel(opts.level)
+	if err != nil {
+		return err
+	}
+	mode, err := log.ToLogMode(opts.mode)
+	if err != nil {
+		return err
+	}
+	encoder, err := log.ToLogEncoder(opts.encoder)
+	if err != nil {
+		return err
+	}
+	timeEncoder, err := log.ToTimeEncoder(opts.timeFormat)
+	if err != nil {
+		return err
+	}
+	logOpts := log.Options{
+		Mode:        mode,
+		Encoder:     encoder,
+		TimeEncoder: timeEncoder,
+	}
+	if opts.samplingInitial > 0 && opts.samplingThereafter > 0 {
+		logOpts.Sampling = &log.Sampling{Initial: opts.samplingInitial, Thereafter: opts.samplingThereafter}
+	}
+	// shared by the logger of the operator, also set as the logger of controller-runtime and klog, and changed at
+	// runtime at the /log-level endpoint
+	log.SetLevel(level)
 	logger = log.NewLogger(logOpts).WithName("authorino-operator").WithName("controller").WithName("Authorino")
 	log.SetLogger(logger)
+	return nil
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "render" {
+		if err := render(os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
 	var pprofAddr string
+	var secureMetrics bool
+	var configPath string
 	logOpts := logOptions{}
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.StringVar(&pprofAddr, "pprof-bind-address", ":8084", "The address the pprof endpoint binds to.")
+	flag.BoolVar(&secureMetrics, "metrics-secure", false,
+		"Serve the metrics endpoint over HTTPS, authenticating and authorizing the requests against the Kubernetes API. "+
+			"Enabling this also serves the /log-level endpoint, to change the log level of the operator at runtime.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.StringVar(&configPath, "config", "",
+		"Path to the operator configuration file (e.g. mounted from a ConfigMap), reloaded when it changes.")
 	flag.StringVar(&logOpts.level, "log-level", "info", "Log level (info, debug, error, etc.)")
 	flag.StringVar(&logOpts.mode, "log-mode", "production", "Log mode (development or production)")
-
-	opts := zap.Options{
-		Development: true,
-	}
+	flag.StringVar(&logOpts.encoder, "log-encoder", "", "Log encoder (json or console). Defaults to the encoder of the log mode")
+	flag.StringVar(&logOpts.timeFormat, "log-time-format", "", "Format of the log timestamps (epoch, millis, nanos, iso8601, rfc3339 or rfc3339nano)")
+	flag.IntVar(&logOpts.samplingInitial, "log-sampling-initial", 0, "Log entries with the same level and message logged each second before sampling (0 to disable)")
+	flag.IntVar(&logOpts.samplingThereafter, "log-sampling-thereafter", 0, "Past the initial log entries, every Nth entry with the same level and message is logged each second")
+
+	// the --zap-* flags of controller-runtime are accepted for compatibility, but ignored: the logger of controller-runtime
+	// is the one of the operator, built from the --log-* flags
+	opts := zap.Options{}
 	opts.BindFlags(flag.CommandLine)
 	flag.Parse()
 
-	setupLogger(logOpts)
-
-	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+	if err := setupLogger(logOpts); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
 
 	setupLog.Info("booting up authorino operator",
 		"version", version,
@@ -104,14 +160,46 @@ func main() {
 		"go version", runtime.Version(),
 		"go os/arch", fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH))
 
+	// the operator configuration must be valid at startup; later, invalid changes are ignored
+	var configWatcher *config.Watcher
+	configChanged := make(chan event.GenericEvent, 1)
+	if configPath != "" {
+		configWatcher = &config.Watcher{Path: configPath, Log: setupLog.WithName("config")}
+		if _, err := configWatcher.Load(); err != nil {
+			setupLog.Error(err, "unable to load the operator configuration", "path", configPath)
+			os.Exit(1)
+		}
+		maxConcurrentReconciles := config.Current().MaxConcurrentReconciles
+		configWatcher.OnChange = func(c *config.OperatorConfig) {
+			if c.MaxConcurrentReconciles != maxConcurrentReconciles {
+				setupLog.Info("maxConcurrentReconciles changed, restart the operator to apply it")
+			}
+			select {
+			case configChanged <- event.GenericEvent{Object: &authorinooperatorv1beta1.Authorino{}}:
+			default: // a reconciliation of all the instances is already pending
+			}
+		}
+	}
+
+	metricsServerOptions := metricsserver.Options{BindAddress: metricsAddr}
+	if secureMetrics {
+		metricsServerOptions.SecureServing = true
+		metricsServerOptions.FilterProvider = filters.WithAuthenticationAndAuthorization
+		// GET reports the log level, PUT changes it (e.g. {"level":"debug"}), for the clients granted the
+		// get and put verbs on the /log-level non-resource URL
+		metricsServerOptions.ExtraHandlers = map[string]http.Handler{"/log-level": log.LevelHandler()}
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                 scheme,
-		Metrics:                metricsserver.Options{BindAddress: metricsAddr},
+		Metrics:                metricsServerOptions,
 		WebhookServer:          webhook.NewServer(webhook.Options{Port: 9443}),
 		HealthProbeBindAddress: probeAddr,
 		PprofBindAddress:       pprofAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "aac3a15d.authorino.kuadrant.io",
+		// the AuthConfigs and the resources of the optional APIs are read as unstructured, from the cache too
+		Client: client.Options{Cache: &client.CacheOptions{Unstructured: true}},
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
@@ -119,17 +207,33 @@ func main() {
 	}
 
 	authorinoReconciler := &reconcilers.AuthorinoReconciler{
-		Client: mgr.GetClient(),
-		Log:    logger,
-		Scheme: mgr.GetScheme(),
+		Client:   mgr.GetClient(),
+		Log:      logger,
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorder("authorino-operator"),
 	}
 
 	if err = (&controllers.AuthorinoReconciler{
 		AuthorinoReconciler: authorinoReconciler,
+		ConfigChanged:       configChanged,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Authorino")
 		os.Exit(1)
 	}
+
+	if err = (&controllers.AuthorinoAuthConfigsReconciler{
+		AuthorinoReconciler: authorinoReconciler,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "AuthorinoAuthConfigs")
+		os.Exit(1)
+	}
+
+	if configWatcher != nil {
+		if err := mgr.Add(configWatcher); err != nil {
+			setupLog.Error(err, "unable to set up the operator configuration watcher")
+			os.Exit(1)
+		}
+	}
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
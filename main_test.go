@@ -0,0 +1,22 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSetupLoggerErrors(t *testing.T) {
+	for _, tc := range []struct {
+		opts     logOptions
+		expected string
+	}{
+		{logOptions{level: "verbose"}, `unknown log level "verbose"`},
+		{logOptions{level: "info", mode: "dev"}, `unknown log mode "dev"`},
+		{logOptions{level: "info", mode: "production", encoder: "text"}, `unknown log encoder "text"`},
+		{logOptions{level: "info", mode: "production", timeFormat: "unix"}, `unknown log time format "unix"`},
+	} {
+		if err := setupLogger(tc.opts); err == nil || !strings.Contains(err.Error(), tc.expected) {
+			t.Errorf("expected error containing %q for %+v, got %v", tc.expected, tc.opts, err)
+		}
+	}
+}
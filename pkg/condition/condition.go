@@ -1,6 +1,8 @@
 package condition
 
 import (
+	"slices"
+
 	api "github.com/kuadrant/authorino-operator/api/v1beta1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -31,7 +33,8 @@ func addOrUpdateStatusCondition(conditions []api.Condition, newCondition api.Con
 			// Condition already present. Update it if needed.
 			if cond.Status == newCondition.Status &&
 				cond.Reason == newCondition.Reason &&
-				cond.Message == newCondition.Message {
+				cond.Message == newCondition.Message &&
+				(newCondition.LastUpdatedTime == nil || newCondition.LastUpdatedTime.Equal(cond.LastUpdatedTime)) {
 				// Nothing changed. No need to update.
 				return conditions, false
 			}
@@ -49,3 +52,16 @@ func addOrUpdateStatusCondition(conditions []api.Condition, newCondition api.Con
 	}
 	return append(conditions, newCondition), true
 }
+
+// RemoveStatusConditions removes the conditions of the given types from the array of conditions
+// the resulting array of conditions is returned with a flag indicating if the conditions were updated or not
+func RemoveStatusConditions(conditions []api.Condition, conditionTypes ...api.ConditionType) ([]api.Condition, bool) {
+	var res []api.Condition
+	for _, cond := range conditions {
+		if slices.Contains(conditionTypes, cond.Type) {
+			continue
+		}
+		res = append(res, cond)
+	}
+	return res, len(res) != len(conditions)
+}
@@ -0,0 +1,217 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-logr/logr"
+	k8score "k8s.io/api/core/v1"
+	k8srbac "k8s.io/api/rbac/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// watch scopes of the Authorino instances
+const (
+	ScopeNamespaced  = "Namespaced"
+	ScopeClusterWide = "ClusterWide"
+)
+
+// DefaultReloadInterval is how often the operator configuration file is checked for changes
+const DefaultReloadInterval = 10 * time.Second
+
+// OperatorConfig is the configuration of the operator, read from a file (e.g. mounted from a ConfigMap).
+// Empty fields fall back to the defaults of the operator.
+type OperatorConfig struct {
+	// Authorino image of the instances that do not set one.
+	// Defaults to the RELATED_IMAGE_AUTHORINO env var, then to the image the operator was built with.
+	DefaultAuthorinoImage string `json:"defaultAuthorinoImage,omitempty"`
+
+	// Compute resources of the Authorino containers.
+	DefaultResources *k8score.ResourceRequirements `json:"defaultResources,omitempty"`
+
+	// Watch scopes allowed to the Authorino instances (Namespaced and ClusterWide).
+	// Empty allows all of them.
+	AllowedScopes []string `json:"allowedScopes,omitempty"`
+
+	// Names of the ClusterRoles bound to the service accounts of the Authorino instances.
+	ClusterRoles ClusterRoles `json:"clusterRoles,omitempty"`
+
+	// Other ClusterRoles the Authorino instances may pick in spec.rbac, instead of the ones of clusterRoles.
+	// Empty allows none.
+	AllowedClusterRoles []string `json:"allowedClusterRoles,omitempty"`
+
+	// Extra permissions the Authorino instances may be granted in spec.rbac.rules. Each rule of an instance must be
+	// covered by one of these. Empty allows none.
+	// The operator must hold these permissions itself, as it cannot grant more than it has.
+	AllowedRules []k8srbac.PolicyRule `json:"allowedRules,omitempty"`
+
+	// ConfigMap with the Istio mesh config (key "mesh"), where the Authorino instances with the Istio integration
+	// register their extension providers. Defaults to istio-system/istio.
+	IstioMeshConfigMap *ObjectReference `json:"istioMeshConfigMap,omitempty"`
+
+	// Maximum number of Authorino instances reconciled concurrently. Only read when the operator starts.
+	MaxConcurrentReconciles int `json:"maxConcurrentReconciles,omitempty"`
+}
+
+type ClusterRoles struct {
+	// ClusterRole with the permissions of Authorino to watch AuthConfigs and Secrets.
+	Manager string `json:"manager,omitempty"`
+	// ClusterRole with the permissions of Authorino to create TokenReviews and SubjectAccessReviews.
+	K8sAuth string `json:"k8sAuth,omitempty"`
+}
+
+type ObjectReference struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// ScopeAllowed tells whether Authorino instances are allowed the watch scope
+func (c *OperatorConfig) ScopeAllowed(scope string) bool {
+	return len(c.AllowedScopes) == 0 || slices.Contains(c.AllowedScopes, scope)
+}
+
+// ClusterRoleAllowed tells whether Authorino instances are allowed to pick the ClusterRole in spec.rbac
+func (c *OperatorConfig) ClusterRoleAllowed(name string) bool {
+	return name != "" && slices.Contains(c.AllowedClusterRoles, name)
+}
+
+// RuleAllowed tells whether Authorino instances are allowed to be granted the extra permissions of the rule, i.e.
+// whether one of the allowed rules covers all of its API groups, resources, resource names, non-resource URLs and verbs
+func (c *OperatorConfig) RuleAllowed(rule k8srbac.PolicyRule) bool {
+	return slices.ContainsFunc(c.AllowedRules, func(allowed k8srbac.PolicyRule) bool {
+		return covers(allowed.APIGroups, rule.APIGroups) &&
+			covers(allowed.Resources, rule.Resources) &&
+			covers(allowed.NonResourceURLs, rule.NonResourceURLs) &&
+			covers(allowed.Verbs, rule.Verbs) &&
+			(len(allowed.ResourceNames) == 0 || len(rule.ResourceNames) > 0 && covers(allowed.ResourceNames, rule.ResourceNames))
+	})
+}
+
+// covers tells whether all the values are in the allowed ones, where "*" allows any value
+func covers(allowed, values []string) bool {
+	if slices.Contains(allowed, k8srbac.ResourceAll) {
+		return true
+	}
+	for _, value := range values {
+		if !slices.Contains(allowed, value) {
+			return false
+		}
+	}
+	return true
+}
+
+// Validate checks the values of the operator configuration
+func (c *OperatorConfig) Validate() error {
+	for _, scope := range c.AllowedScopes {
+		if scope != ScopeNamespaced && scope != ScopeClusterWide {
+			return fmt.Errorf("unknown watch scope %q in allowedScopes, use %s or %s", scope, ScopeNamespaced, ScopeClusterWide)
+		}
+	}
+	if ref := c.IstioMeshConfigMap; ref != nil && (ref.Namespace == "" || ref.Name == "") {
+		return fmt.Errorf("istioMeshConfigMap must set both namespace and name")
+	}
+	if c.MaxConcurrentReconciles < 0 {
+		return fmt.Errorf("maxConcurrentReconciles must not be negative, got %d", c.MaxConcurrentReconciles)
+	}
+	return nil
+}
+
+// Parse reads the operator configuration from YAML or JSON, rejecting unknown fields
+func Parse(data []byte) (*OperatorConfig, error) {
+	c := &OperatorConfig{}
+	if err := yaml.UnmarshalStrict(data, c); err != nil {
+		return nil, fmt.Errorf("failed to parse the operator configuration: %v", err)
+	}
+	if err := c.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid operator configuration: %v", err)
+	}
+	return c, nil
+}
+
+var current atomic.Pointer[OperatorConfig]
+
+// Current returns the operator configuration in use, which is empty (i.e. all defaults) until Set is called
+func Current() *OperatorConfig {
+	if c := current.Load(); c != nil {
+		return c
+	}
+	return &OperatorConfig{}
+}
+
+// Set replaces the operator configuration in use
+func Set(c *OperatorConfig) {
+	current.Store(c)
+}
+
+// Watcher reloads the operator configuration from a file whenever its content changes, polling it, so it also picks up
+// the updates of a mounted ConfigMap (which the kubelet swaps by symlink).
+// An invalid configuration is logged once and ignored, keeping the one in use, until the content of the file changes.
+type Watcher struct {
+	Path     string
+	Interval time.Duration
+	Log      logr.Logger
+	// OnChange is called after a new configuration is set
+	OnChange func(*OperatorConfig)
+
+	// data is the content of the file last tried, whether it parsed or not
+	data []byte
+}
+
+// Load reads the configuration file and sets the configuration in use, if the content of the file changed since it
+// was last tried. It returns whether the configuration changed, and the parsing error of new content only.
+func (w *Watcher) Load() (bool, error) {
+	data, err := os.ReadFile(w.Path)
+	if err != nil {
+		return false, fmt.Errorf("failed to read the operator configuration: %v", err)
+	}
+	if w.data != nil && bytes.Equal(data, w.data) {
+		return false, nil
+	}
+	w.data = data
+	c, err := Parse(data)
+	if err != nil {
+		return false, err
+	}
+	Set(c)
+	return true, nil
+}
+
+// Start polls the configuration file until the context is done. It implements the manager.Runnable interface.
+func (w *Watcher) Start(ctx context.Context) error {
+	interval := w.Interval
+	if interval <= 0 {
+		interval = DefaultReloadInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			changed, err := w.Load()
+			if err != nil {
+				w.Log.Error(err, "failed to reload the operator configuration, keeping the one in use", "path", w.Path)
+				continue
+			}
+			if !changed {
+				continue
+			}
+			w.Log.Info("operator configuration reloaded", "path", w.Path)
+			if w.OnChange != nil {
+				w.OnChange(Current())
+			}
+		}
+	}
+}
+
+// NeedLeaderElection tells the manager to run the Watcher on every replica of the operator
+func (w *Watcher) NeedLeaderElection() bool {
+	return false
+}
@@ -0,0 +1,173 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/funcr"
+	k8srbac "k8s.io/api/rbac/v1"
+)
+
+func TestParse(t *testing.T) {
+	c, err := Parse([]byte(`
+defaultAuthorinoImage: quay.io/kuadrant/authorino:v0.20.0
+defaultResources:
+  requests:
+    cpu: 100m
+allowedScopes:
+- Namespaced
+clusterRoles:
+  manager: custom-manager-role
+maxConcurrentReconciles: 4
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.DefaultAuthorinoImage != "quay.io/kuadrant/authorino:v0.20.0" {
+		t.Errorf("unexpected default image %q", c.DefaultAuthorinoImage)
+	}
+	if cpu := c.DefaultResources.Requests.Cpu().String(); cpu != "100m" {
+		t.Errorf("unexpected default cpu request %s", cpu)
+	}
+	if c.ClusterRoles.Manager != "custom-manager-role" || c.ClusterRoles.K8sAuth != "" {
+		t.Errorf("unexpected cluster roles %+v", c.ClusterRoles)
+	}
+	if c.MaxConcurrentReconciles != 4 {
+		t.Errorf("unexpected max concurrent reconciles %d", c.MaxConcurrentReconciles)
+	}
+	if !c.ScopeAllowed(ScopeNamespaced) || c.ScopeAllowed(ScopeClusterWide) {
+		t.Errorf("expected only the Namespaced scope to be allowed, got %v", c.AllowedScopes)
+	}
+
+	if c, err := Parse(nil); err != nil || !c.ScopeAllowed(ScopeClusterWide) {
+		t.Errorf("expected an empty configuration to allow all scopes, got %+v, err: %v", c, err)
+	}
+
+	for data, expected := range map[string]string{
+		"maxConcurrentReconciles: -1":       "must not be negative",
+		"allowedScopes: [Cluster]":          `unknown watch scope "Cluster"`,
+		"defaultImage: quay.io/authorino":   "unknown field",
+		"clusterRoles: authorino-manager":   "failed to parse",
+		"allowedScopes: Namespaced":         "failed to parse",
+		"clusterRoles: {leaderElection: a}": "unknown field",
+		"istioMeshConfigMap: {name: istio}": "must set both namespace and name",
+	} {
+		if _, err := Parse([]byte(data)); err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected error containing %q parsing %q, got %v", expected, data, err)
+		}
+	}
+}
+
+func TestRBACAllowed(t *testing.T) {
+	c, err := Parse([]byte(`
+allowedClusterRoles:
+- tenant-manager-role
+allowedRules:
+- apiGroups: [""]
+  resources: [configmaps, services]
+  verbs: [get, list, watch]
+- apiGroups: [apps]
+  resources: ["*"]
+  resourceNames: [authorino]
+  verbs: [get]
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !c.ClusterRoleAllowed("tenant-manager-role") || c.ClusterRoleAllowed("cluster-admin") || c.ClusterRoleAllowed("") {
+		t.Errorf("expected only the tenant-manager-role ClusterRole to be allowed, got %v", c.AllowedClusterRoles)
+	}
+
+	for _, tc := range []struct {
+		rule    k8srbac.PolicyRule
+		allowed bool
+	}{
+		{k8srbac.PolicyRule{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"get", "list"}}, true},
+		{k8srbac.PolicyRule{APIGroups: []string{""}, Resources: []string{"configmaps"}, ResourceNames: []string{"a"}, Verbs: []string{"get"}}, true},
+		{k8srbac.PolicyRule{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"get"}}, false},
+		{k8srbac.PolicyRule{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"update"}}, false},
+		{k8srbac.PolicyRule{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}}, false},
+		{k8srbac.PolicyRule{APIGroups: []string{"apps"}, Resources: []string{"deployments"}, ResourceNames: []string{"authorino"}, Verbs: []string{"get"}}, true},
+		{k8srbac.PolicyRule{APIGroups: []string{"apps"}, Resources: []string{"deployments"}, Verbs: []string{"get"}}, false},
+		{k8srbac.PolicyRule{NonResourceURLs: []string{"/metrics"}, Verbs: []string{"get"}}, false},
+	} {
+		if allowed := c.RuleAllowed(tc.rule); allowed != tc.allowed {
+			t.Errorf("expected rule %+v allowed=%v, got %v", tc.rule, tc.allowed, allowed)
+		}
+	}
+
+	if (&OperatorConfig{}).RuleAllowed(k8srbac.PolicyRule{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"get"}}) {
+		t.Errorf("expected no rule to be allowed by default")
+	}
+}
+
+func TestWatcher(t *testing.T) {
+	t.Cleanup(func() { Set(nil) })
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	// replaced atomically, as the kubelet does with a mounted ConfigMap, so the watcher never reads a truncated file
+	write := func(data string) {
+		t.Helper()
+		if err := os.WriteFile(path+".tmp", []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Rename(path+".tmp", path); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("defaultAuthorinoImage: quay.io/kuadrant/authorino:v1\n")
+	changes := make(chan *OperatorConfig, 3)
+	var errorsLogged atomic.Int32
+	log := funcr.New(func(_, args string) {
+		if strings.Contains(args, `"error"`) {
+			errorsLogged.Add(1)
+		}
+	}, funcr.Options{})
+	w := &Watcher{Path: path, Interval: 10 * time.Millisecond, Log: log, OnChange: func(c *OperatorConfig) { changes <- c }}
+	if changed, err := w.Load(); err != nil || !changed {
+		t.Fatalf("expected the configuration to be loaded, got changed=%v, err: %v", changed, err)
+	}
+	if image := Current().DefaultAuthorinoImage; image != "quay.io/kuadrant/authorino:v1" {
+		t.Errorf("unexpected default image %q", image)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = w.Start(ctx) }()
+
+	// invalid changes are ignored, and logged once
+	write("maxConcurrentReconciles: -1\n")
+	time.Sleep(50 * time.Millisecond)
+	if image := Current().DefaultAuthorinoImage; image != "quay.io/kuadrant/authorino:v1" {
+		t.Errorf("expected the configuration in use to be kept, got default image %q", image)
+	}
+	if n := errorsLogged.Load(); n != 1 {
+		t.Errorf("expected the invalid configuration to be logged once, got %d times", n)
+	}
+
+	write("defaultAuthorinoImage: quay.io/kuadrant/authorino:v2\n")
+	select {
+	case c := <-changes:
+		if c.DefaultAuthorinoImage != "quay.io/kuadrant/authorino:v2" {
+			t.Errorf("unexpected default image %q reloaded", c.DefaultAuthorinoImage)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the configuration to be reloaded")
+	}
+	if image := Current().DefaultAuthorinoImage; image != "quay.io/kuadrant/authorino:v2" {
+		t.Errorf("unexpected default image %q", image)
+	}
+
+	// unchanged content is not reloaded
+	time.Sleep(50 * time.Millisecond)
+	if len(changes) > 0 {
+		t.Errorf("unexpected reload of unchanged configuration")
+	}
+}
@@ -2,9 +2,14 @@ package log
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
+	uberzap "go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -20,6 +25,10 @@ var (
 	// until `SetLogger` is called.
 	// This is also useful for mocking the default logger tests.
 	Log Logger = ctrl.Log
+
+	// level is the log level shared by all the loggers created by NewLogger, set with SetLevel or LevelHandler, also
+	// at runtime, without recreating the loggers
+	level = uberzap.NewAtomicLevel()
 )
 
 type Logger = logr.Logger
@@ -31,10 +40,30 @@ func (l *LogLevel) String() string {
 }
 
 // ToLogLevel converts a string to a log level.
-func ToLogLevel(level string) LogLevel {
+// Use one of 'debug', 'info', 'warn', 'error', 'dpanic', 'panic' or 'fatal'; empty means 'info'.
+func ToLogLevel(level string) (LogLevel, error) {
 	var l zapcore.Level
-	_ = l.UnmarshalText([]byte(level))
-	return LogLevel(l)
+	if err := l.UnmarshalText([]byte(level)); err != nil {
+		return LogLevel(zapcore.InfoLevel), fmt.Errorf("unknown log level %q, use one of debug, info, warn, error, dpanic, panic or fatal", level)
+	}
+	return LogLevel(l), nil
+}
+
+// GetLevel returns the current log level of the loggers created by NewLogger.
+func GetLevel() LogLevel {
+	return LogLevel(level.Level())
+}
+
+// SetLevel changes the log level of the loggers created by NewLogger.
+func SetLevel(l LogLevel) {
+	level.SetLevel(zapcore.Level(l))
+}
+
+// LevelHandler returns an HTTP handler that reports (GET) and changes (PUT) the log level of the loggers created by
+// NewLogger, e.g. `curl -X PUT -d '{"level":"debug"}'`. That includes the logger of controller-runtime and klog, once
+// one of those loggers is set with SetLogger.
+func LevelHandler() http.Handler {
+	return level
 }
 
 // LogMode defines the log output mode.
@@ -61,21 +90,86 @@ func (f *LogMode) String() string {
 
 // ToLogMode converts a string to a log mode.
 // Use either 'production' for `LogModeProd` or 'development' for `LogModeDev`.
-func ToLogMode(mode string) LogMode {
+func ToLogMode(mode string) (LogMode, error) {
 	switch strings.ToLower(mode) {
 	case "production":
-		return LogModeProd
+		return LogModeProd, nil
 	case "development":
-		return LogModeDev
+		return LogModeDev, nil
+	default:
+		return LogModeProd, fmt.Errorf("unknown log mode %q, use either production or development", mode)
+	}
+}
+
+// LogEncoder defines the encoding of the log entries.
+type LogEncoder int8
+
+const (
+	// LogEncoderDefault is the encoder of the log mode, i.e. JSON in production and console in development.
+	LogEncoderDefault LogEncoder = iota
+	// LogEncoderJSON encodes the log entries as JSON objects.
+	LogEncoderJSON
+	// LogEncoderConsole encodes the log entries as human-readable lines.
+	LogEncoderConsole
+)
+
+func (e *LogEncoder) String() string {
+	switch *e {
+	case LogEncoderJSON:
+		return "json"
+	case LogEncoderConsole:
+		return "console"
 	default:
-		panic("unknown log mode")
+		return ""
 	}
 }
 
+// ToLogEncoder converts a string to a log encoder.
+// Use either 'json' for `LogEncoderJSON` or 'console' for `LogEncoderConsole`; empty means `LogEncoderDefault`.
+func ToLogEncoder(encoder string) (LogEncoder, error) {
+	switch strings.ToLower(encoder) {
+	case "":
+		return LogEncoderDefault, nil
+	case "json":
+		return LogEncoderJSON, nil
+	case "console":
+		return LogEncoderConsole, nil
+	default:
+		return LogEncoderDefault, fmt.Errorf("unknown log encoder %q, use either json or console", encoder)
+	}
+}
+
+// timeFormats are the formats of the timestamps of the log entries, as understood by zapcore.TimeEncoder
+var timeFormats = []string{"epoch", "millis", "nanos", "iso8601", "rfc3339", "rfc3339nano"}
+
+// ToTimeEncoder converts a string to an encoder of the timestamps of the log entries.
+// Use one of 'epoch', 'millis', 'nanos', 'iso8601', 'rfc3339' or 'rfc3339nano'; empty means the default (rfc3339).
+func ToTimeEncoder(format string) (zapcore.TimeEncoder, error) {
+	if format == "" {
+		return nil, nil
+	}
+	if !slices.Contains(timeFormats, format) {
+		return nil, fmt.Errorf("unknown log time format %q, use one of %s", format, strings.Join(timeFormats, ", "))
+	}
+	var e zapcore.TimeEncoder
+	_ = e.UnmarshalText([]byte(format))
+	return e, nil
+}
+
+// Sampling caps the log entries with the same level and message logged each second to the first `Initial` ones, and
+// every `Thereafter`th one past those.
+type Sampling struct {
+	Initial    int
+	Thereafter int
+}
+
 // Options is a set of options for a configured logger.
+// The level is not an option, as it is shared by all the loggers created by NewLogger (see SetLevel).
 type Options struct {
-	Level LogLevel
-	Mode  LogMode
+	Mode        LogMode
+	Encoder     LogEncoder
+	TimeEncoder zapcore.TimeEncoder
+	Sampling    *Sampling
 }
 
 // SetLogger sets up a logger.
@@ -121,9 +215,30 @@ func FromContext(ctx context.Context, keysAndValues ...interface{}) Logger {
 // NewLogger returns a new logger with the given options.
 // `logger` param is the actual logger implementation; when omitted, a new
 // logger based on sigs.k8s.io/controller-runtime/pkg/log/zap is created.
+// The log level is the one shared by all the loggers created by NewLogger, set with SetLevel.
 func NewLogger(opts Options) Logger {
-	return zap.New(
-		zap.Level(zapcore.Level(opts.Level)),
+	zapOpts := []zap.Opts{
+		zap.Level(level),
 		zap.UseDevMode(opts.Mode == LogModeDev),
-	)
+	}
+
+	switch opts.Encoder {
+	case LogEncoderJSON:
+		zapOpts = append(zapOpts, zap.JSONEncoder())
+	case LogEncoderConsole:
+		zapOpts = append(zapOpts, zap.ConsoleEncoder())
+	}
+
+	if opts.TimeEncoder != nil {
+		zapOpts = append(zapOpts, func(o *zap.Options) { o.TimeEncoder = opts.TimeEncoder })
+	}
+
+	// entries more verbose than debug are not sampled, as the zap sampler only counts entries from debug up
+	if s := opts.Sampling; s != nil && s.Initial > 0 && s.Thereafter > 0 {
+		zapOpts = append(zapOpts, zap.RawZapOpts(uberzap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return zapcore.NewSamplerWithOptions(core, time.Second, s.Initial, s.Thereafter)
+		})))
+	}
+
+	return zap.New(zapOpts...)
 }
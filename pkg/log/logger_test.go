@@ -1,8 +1,13 @@
 package log
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
 	"testing"
 
+	"go.uber.org/zap/zapcore"
 	"gotest.tools/assert"
 )
 
@@ -29,15 +34,28 @@ func TestLogLevelToString(t *testing.T) {
 	assert.Equal(t, level.String(), "fatal")
 }
 
+func mustLogLevel(t *testing.T, level string) LogLevel {
+	t.Helper()
+	l, err := ToLogLevel(level)
+	assert.NilError(t, err)
+	return l
+}
+
 func TestToLogLevel(t *testing.T) {
-	assert.Equal(t, int(ToLogLevel("debug")), -1)
-	assert.Equal(t, int(ToLogLevel("info")), 0)
-	assert.Equal(t, int(ToLogLevel("warn")), 1)
-	assert.Equal(t, int(ToLogLevel("error")), 2)
-	assert.Equal(t, int(ToLogLevel("dpanic")), 3)
-	assert.Equal(t, int(ToLogLevel("panic")), 4)
-	assert.Equal(t, int(ToLogLevel("fatal")), 5)
-	assert.Equal(t, int(ToLogLevel("invalid")), 0) // falls back to default log level (info) without panicking
+	for level, expected := range map[string]int{
+		"debug":  -1,
+		"info":   0,
+		"":       0,
+		"warn":   1,
+		"error":  2,
+		"dpanic": 3,
+		"panic":  4,
+		"fatal":  5,
+	} {
+		assert.Equal(t, int(mustLogLevel(t, level)), expected, level)
+	}
+	_, err := ToLogLevel("invalid")
+	assert.ErrorContains(t, err, `unknown log level "invalid"`)
 }
 
 func TestLogModeToString(t *testing.T) {
@@ -49,13 +67,115 @@ func TestLogModeToString(t *testing.T) {
 }
 
 func TestToLogMode(t *testing.T) {
-	assert.Equal(t, int(ToLogMode("production")), 0)
-	assert.Equal(t, int(ToLogMode("development")), 1)
-
-	defer func() {
-		if r := recover(); r == nil {
-			t.Errorf(`ToLogMode("invalid") was expected to panic and it did not.`)
-		}
-	}()
-	_ = ToLogMode("invalid")
+	for mode, expected := range map[string]LogMode{
+		"production":  LogModeProd,
+		"development": LogModeDev,
+		"Development": LogModeDev,
+	} {
+		m, err := ToLogMode(mode)
+		assert.NilError(t, err)
+		assert.Equal(t, m, expected, mode)
+	}
+	_, err := ToLogMode("invalid")
+	assert.ErrorContains(t, err, `unknown log mode "invalid"`)
+}
+
+func TestLogEncoderToString(t *testing.T) {
+	encoder := LogEncoder(0)
+	assert.Equal(t, encoder.String(), "")
+
+	encoder = LogEncoder(1)
+	assert.Equal(t, encoder.String(), "json")
+
+	encoder = LogEncoder(2)
+	assert.Equal(t, encoder.String(), "console")
+}
+
+func TestToLogEncoder(t *testing.T) {
+	for encoder, expected := range map[string]LogEncoder{
+		"json":    LogEncoderJSON,
+		"console": LogEncoderConsole,
+		"":        LogEncoderDefault,
+	} {
+		e, err := ToLogEncoder(encoder)
+		assert.NilError(t, err)
+		assert.Equal(t, e, expected, encoder)
+	}
+	_, err := ToLogEncoder("invalid")
+	assert.ErrorContains(t, err, `unknown log encoder "invalid"`)
+}
+
+func TestToTimeEncoder(t *testing.T) {
+	e, err := ToTimeEncoder("")
+	assert.NilError(t, err)
+	assert.Assert(t, e == nil)
+
+	for format, expected := range map[string]zapcore.TimeEncoder{
+		"epoch":       zapcore.EpochTimeEncoder,
+		"millis":      zapcore.EpochMillisTimeEncoder,
+		"nanos":       zapcore.EpochNanosTimeEncoder,
+		"iso8601":     zapcore.ISO8601TimeEncoder,
+		"rfc3339":     zapcore.RFC3339TimeEncoder,
+		"rfc3339nano": zapcore.RFC3339NanoTimeEncoder,
+	} {
+		e, err := ToTimeEncoder(format)
+		assert.NilError(t, err)
+		assert.Equal(t, reflect.ValueOf(e).Pointer(), reflect.ValueOf(expected).Pointer(), format)
+	}
+
+	_, err = ToTimeEncoder("invalid")
+	assert.ErrorContains(t, err, `unknown log time format "invalid"`)
+}
+
+func TestNewLogger(t *testing.T) {
+	SetLevel(mustLogLevel(t, "debug"))
+	logger := NewLogger(Options{
+		Mode:        LogModeProd,
+		Encoder:     LogEncoderConsole,
+		TimeEncoder: zapcore.RFC3339NanoTimeEncoder,
+		Sampling:    &Sampling{Initial: 10, Thereafter: 100},
+	})
+	assert.Assert(t, logger.V(1).Enabled())
+	assert.Assert(t, !logger.V(2).Enabled())
+}
+
+func TestSetLevel(t *testing.T) {
+	SetLevel(mustLogLevel(t, "info"))
+	logger := NewLogger(Options{})
+	other := NewLogger(Options{Mode: LogModeDev})
+	assert.Assert(t, !logger.V(1).Enabled())
+
+	// the level is shared by the loggers
+	SetLevel(mustLogLevel(t, "debug"))
+	assert.Assert(t, logger.V(1).Enabled())
+	assert.Assert(t, other.V(1).Enabled())
+	assert.Equal(t, GetLevel(), mustLogLevel(t, "debug"))
+
+	// creating a logger does not change the level
+	_ = NewLogger(Options{})
+	assert.Equal(t, GetLevel(), mustLogLevel(t, "debug"))
+
+	SetLevel(mustLogLevel(t, "error"))
+	assert.Assert(t, !logger.Enabled())
+}
+
+func TestLevelHandler(t *testing.T) {
+	SetLevel(mustLogLevel(t, "info"))
+	logger := NewLogger(Options{})
+	handler := LevelHandler()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/log-level", strings.NewReader(`{"level":"debug"}`)))
+	assert.Equal(t, rec.Code, http.StatusOK)
+	assert.Assert(t, logger.V(1).Enabled())
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/log-level", nil))
+	assert.Equal(t, rec.Code, http.StatusOK)
+	assert.Equal(t, strings.TrimSpace(rec.Body.String()), `{"level":"debug"}`)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/log-level", strings.NewReader(`{"level":"invalid"}`)))
+	assert.Equal(t, rec.Code, http.StatusBadRequest)
+	assert.Equal(t, GetLevel(), mustLogLevel(t, "debug"))
 }
@@ -0,0 +1,73 @@
+package reconcilers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/kuadrant/authorino-operator/api/v1beta1"
+	authorinoResources "github.com/kuadrant/authorino-operator/pkg/resources"
+)
+
+// ReconcileAuthorinoAuthConfigsStatus aggregates the readiness of the AuthConfigs in the watch scope of the Authorino
+// instance (watched namespaces and AuthConfig label selectors, including the shard) into its status.
+// Nothing is reported when the AuthConfig API is not available in the cluster.
+func (r *AuthorinoReconciler) ReconcileAuthorinoAuthConfigsStatus(ctx context.Context, authorinoInstance *api.Authorino) error {
+	logger, err := logr.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	available, err := r.apiAvailable(authorinoResources.AuthConfigGVK)
+	if err != nil {
+		return r.WrapErrorWithStatusUpdate(logger, authorinoInstance, r.SetStatusFailed(statusUnableToGetAuthConfigs),
+			fmt.Errorf("failed to discover the AuthConfig API, err: %v", err))
+	}
+
+	var authConfigsStatus *api.AuthConfigsStatus
+	if available {
+		authConfigs, err := r.listWatchedAuthConfigs(ctx, authorinoInstance, true)
+		if err != nil {
+			return r.WrapErrorWithStatusUpdate(logger, authorinoInstance, r.SetStatusFailed(statusUnableToGetAuthConfigs),
+				fmt.Errorf("failed to list the AuthConfigs of %s, err: %v", authorinoInstance.Name, err))
+		}
+
+		authConfigsStatus = &api.AuthConfigsStatus{Total: int32(len(authConfigs))}
+		var notReady []string
+		for i := range authConfigs {
+			if authorinoResources.AuthConfigReady(&authConfigs[i]) {
+				authConfigsStatus.Ready++
+				continue
+			}
+			notReady = append(notReady, client.ObjectKeyFromObject(&authConfigs[i]).String())
+		}
+		slices.Sort(notReady)
+		if len(notReady) > maxNotReadyAuthConfigs {
+			notReady = notReady[:maxNotReadyAuthConfigs]
+		}
+		authConfigsStatus.NotReady = notReady
+	}
+
+	if !reflect.DeepEqual(authorinoInstance.Status.AuthConfigs, authConfigsStatus) {
+		authorinoInstance.Status.AuthConfigs = authConfigsStatus
+		return r.updateStatusConditions(authorinoInstance)
+	}
+
+	return nil
+}
+
+// AuthConfigInScope tells whether an AuthConfig is in the watch scope of the Authorino instance, regardless of the
+// shard, i.e. whether a change to the AuthConfig may affect the sharding or the status of the instance
+func AuthConfigInScope(authorino *api.Authorino, authConfig client.Object) bool {
+	if namespaces := WatchedNamespaces(authorino); namespaces != nil && !slices.Contains(namespaces, authConfig.GetNamespace()) {
+		return false
+	}
+
+	selector, err := labels.Parse(authorino.Spec.AuthConfigLabelSelectors)
+	return err == nil && selector.Matches(labels.Set(authConfig.GetLabels()))
+}
@@ -3,26 +3,34 @@ package reconcilers
 import (
 	"context"
 	"fmt"
+	"maps"
+	"reflect"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	k8sapps "k8s.io/api/apps/v1"
 	k8score "k8s.io/api/core/v1"
 	k8srbac "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	k8smeta "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	api "github.com/kuadrant/authorino-operator/api/v1beta1"
+	"github.com/kuadrant/authorino-operator/pkg/config"
 	authorinoResources "github.com/kuadrant/authorino-operator/pkg/resources"
 )
 
 type AuthorinoReconciler struct {
 	client.Client
-	Log    logr.Logger
-	Scheme *runtime.Scheme
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder events.EventRecorder
 }
 
 func (r *AuthorinoReconciler) ReconcileAuthorinoDeployment(ctx context.Context, authorinoInstance *api.Authorino) error {
@@ -33,6 +41,10 @@ func (r *AuthorinoReconciler) ReconcileAuthorinoDeployment(ctx context.Context,
 
 	deployment := AuthorinoDeployment(authorinoInstance)
 
+	if err := r.reconcileRollback(ctx, authorinoInstance, deployment); err != nil {
+		return err
+	}
+
 	err = ctrl.SetControllerReference(authorinoInstance, deployment, r.Scheme)
 	if err != nil {
 		return r.WrapErrorWithStatusUpdate(logger, authorinoInstance, r.SetStatusFailed(StatusUnableToBuildDeploymentObject),
@@ -40,6 +52,17 @@ func (r *AuthorinoReconciler) ReconcileAuthorinoDeployment(ctx context.Context,
 		)
 	}
 
+	// Migration: the selector of the Deployment, which cannot be updated, did not tell the pods of the canary Deployment
+	// apart. The Deployment is replaced, and reconciled again once deleted.
+	replaced, err := r.replaceDeploymentWithLegacySelector(ctx, deployment)
+	if err != nil {
+		return r.WrapErrorWithStatusUpdate(logger, authorinoInstance, r.SetStatusFailed(statusUnableToUpdateDeployment),
+			fmt.Errorf("failed to replace %s Deployment resource, err: %v", authorinoInstance.Name, err))
+	}
+	if replaced {
+		return nil
+	}
+
 	err = r.reconcileDeployment(ctx, logger, deployment, authorinoInstance)
 	if err != nil {
 		return fmt.Errorf("failed to reconcile %s Deployment resource, err: %v", authorinoInstance.Name, err)
@@ -47,7 +70,78 @@ func (r *AuthorinoReconciler) ReconcileAuthorinoDeployment(ctx context.Context,
 	return nil
 }
 
+// replaceDeploymentWithLegacySelector deletes the Deployment of the Authorino instance if its selector is other than
+// the desired one, orphaning its ReplicaSets, so their pods keep serving until the Deployment created in its place
+// rolls out. Then, the ReplicaSets orphaned are deleted. It tells whether the Deployment was deleted.
+func (r *AuthorinoReconciler) replaceDeploymentWithLegacySelector(ctx context.Context, desired *k8sapps.Deployment) (bool, error) {
+	logger, _ := logr.FromContext(ctx)
+
+	existing := &k8sapps.Deployment{}
+	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(desired), existing); err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	if existing.Spec.Selector != nil && reflect.DeepEqual(existing.Spec.Selector.MatchLabels, desired.Spec.Selector.MatchLabels) {
+		if DeploymentRolledOut(existing) {
+			r.cleanupOrphanedReplicaSets(ctx, desired)
+		}
+		return false, nil
+	}
+
+	logger.Info("replacing Deployment with legacy selector", "name", existing.Name, "selector", existing.Spec.Selector)
+	preconditions := client.Preconditions{
+		UID:             &existing.UID,
+		ResourceVersion: &existing.ResourceVersion,
+	}
+	if err := r.Client.Delete(ctx, existing, preconditions, client.PropagationPolicy(k8smeta.DeletePropagationOrphan)); err != nil && !errors.IsNotFound(err) {
+		return false, err
+	}
+	return true, nil
+}
+
+// cleanupOrphanedReplicaSets deletes the ReplicaSets of the Authorino instance orphaned by
+// replaceDeploymentWithLegacySelector, i.e. with no controller and a selector other than the one of the Deployment.
+// It is best-effort, as cleanupLegacyClusterRoleBindings.
+func (r *AuthorinoReconciler) cleanupOrphanedReplicaSets(ctx context.Context, deployment *k8sapps.Deployment) {
+	logger, _ := logr.FromContext(ctx)
+
+	legacyLabels := maps.Clone(deployment.Spec.Selector.MatchLabels)
+	delete(legacyLabels, CanaryLabel)
+	replicaSetList := &k8sapps.ReplicaSetList{}
+	if err := r.Client.List(ctx, replicaSetList, client.InNamespace(deployment.Namespace), client.MatchingLabels(legacyLabels)); err != nil {
+		logger.Error(err, "failed to list the ReplicaSets orphaned by the Deployment", "name", deployment.Name)
+		return
+	}
+	for i := range replicaSetList.Items {
+		replicaSet := &replicaSetList.Items[i]
+		if k8smeta.GetControllerOf(replicaSet) != nil || replicaSet.Spec.Selector == nil || !reflect.DeepEqual(replicaSet.Spec.Selector.MatchLabels, legacyLabels) {
+			continue
+		}
+		if err := r.Client.Delete(ctx, replicaSet, client.PropagationPolicy(k8smeta.DeletePropagationBackground)); err != nil && !errors.IsNotFound(err) {
+			logger.Error(err, "failed to delete ReplicaSet orphaned by the Deployment", "name", replicaSet.Name)
+		}
+	}
+}
+
 func (r *AuthorinoReconciler) ReconcileAuthorinoServices(ctx context.Context, authorinoInstance *api.Authorino) error {
+	for _, desiredService := range AuthorinoServices(authorinoInstance) {
+		_ = ctrl.SetControllerReference(authorinoInstance, desiredService, r.Scheme)
+
+		err := r.reconcileService(ctx, desiredService, authorinoInstance)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AuthorinoServices builds the Services of the Authorino instance (auth, OIDC and metrics).
+// Services disabled in the spec are tagged to delete.
+func AuthorinoServices(authorinoInstance *api.Authorino) []*k8score.Service {
 	authorinoInstanceName := authorinoInstance.Name
 	authorinoInstanceNamespace := authorinoInstance.Namespace
 
@@ -55,25 +149,19 @@ func (r *AuthorinoReconciler) ReconcileAuthorinoServices(ctx context.Context, au
 	var grpcPort, httpPort int32
 
 	// auth service
-	if p := authorinoInstance.Spec.Listener.Ports.GRPC; p != nil {
-		grpcPort = *p
-	} else if p := authorinoInstance.Spec.Listener.Port; p != nil { // deprecated
-		grpcPort = *p
-	} else {
-		grpcPort = DefaultAuthGRPCServicePort
-	}
-	if p := authorinoInstance.Spec.Listener.Ports.HTTP; p != nil {
-		httpPort = *p
-	} else {
-		httpPort = DefaultAuthHTTPServicePort
-	}
-	desiredServices = append(desiredServices, authorinoResources.NewAuthService(
+	grpcPort = authGRPCServicePort(authorinoInstance)
+	httpPort = authHTTPServicePort(authorinoInstance)
+	authService := authorinoResources.NewAuthService(
 		authorinoInstanceName,
 		authorinoInstanceNamespace,
 		grpcPort,
 		httpPort,
 		authorinoInstance.Labels,
-	))
+	)
+	if !AuthServiceEnabled(authorinoInstance) {
+		TagObjectToDelete(authService)
+	}
+	desiredServices = append(desiredServices, authService)
 
 	// oidc service
 	if p := authorinoInstance.Spec.OIDCServer.Port; p != nil {
@@ -81,12 +169,16 @@ func (r *AuthorinoReconciler) ReconcileAuthorinoServices(ctx context.Context, au
 	} else {
 		httpPort = DefaultOIDCServicePort
 	}
-	desiredServices = append(desiredServices, authorinoResources.NewOIDCService(
+	oidcService := authorinoResources.NewOIDCService(
 		authorinoInstanceName,
 		authorinoInstanceNamespace,
 		httpPort,
 		authorinoInstance.Labels,
-	))
+	)
+	if !OIDCServerEnabled(authorinoInstance) {
+		TagObjectToDelete(oidcService)
+	}
+	desiredServices = append(desiredServices, oidcService)
 
 	// metrics service
 	if p := authorinoInstance.Spec.Metrics.Port; p != nil {
@@ -94,23 +186,35 @@ func (r *AuthorinoReconciler) ReconcileAuthorinoServices(ctx context.Context, au
 	} else {
 		httpPort = DefaultMetricsServicePort
 	}
-	desiredServices = append(desiredServices, authorinoResources.NewMetricsService(
+	metricsService := authorinoResources.NewMetricsService(
 		authorinoInstanceName,
 		authorinoInstanceNamespace,
 		httpPort,
 		authorinoInstance.Labels,
-	))
+	)
+	if enabled := authorinoInstance.Spec.Metrics.Service.Enabled; enabled != nil && !*enabled {
+		TagObjectToDelete(metricsService)
+	}
+	desiredServices = append(desiredServices, metricsService)
 
-	for _, desiredService := range desiredServices {
-		_ = ctrl.SetControllerReference(authorinoInstance, desiredService, r.Scheme)
+	return desiredServices
+}
 
-		err := r.reconcileService(ctx, desiredService, authorinoInstance)
-		if err != nil {
-			return err
-		}
+func authGRPCServicePort(authorinoInstance *api.Authorino) int32 {
+	if p := authorinoInstance.Spec.Listener.Ports.GRPC; p != nil {
+		return *p
 	}
+	if p := authorinoInstance.Spec.Listener.Port; p != nil { // deprecated
+		return *p
+	}
+	return DefaultAuthGRPCServicePort
+}
 
-	return nil
+func authHTTPServicePort(authorinoInstance *api.Authorino) int32 {
+	if p := authorinoInstance.Spec.Listener.Ports.HTTP; p != nil {
+		return *p
+	}
+	return DefaultAuthHTTPServicePort
 }
 
 func (r *AuthorinoReconciler) ReconcileAuthorinoPermissions(ctx context.Context, authorinoInstance *api.Authorino) error {
@@ -125,6 +229,11 @@ func (r *AuthorinoReconciler) ReconcileAuthorinoPermissions(ctx context.Context,
 		return err
 	}
 
+	// Role or ClusterRole with the extra permissions of the instance, and its bindings
+	if err := r.reconcileRBACRules(ctx, authorinoInstance); err != nil {
+		return err
+	}
+
 	// ClusterRoleBinding for the authorino-manager-k8s-auth-role cluster role
 	// for Authorino's Kubernetes TokenReview and SubjectAccessReview features
 	// Disclaimer: this has nothing to do with kube-rbac-proxy, but to authn/authz features of Authorino that also require cluster scope role bindings
@@ -132,12 +241,12 @@ func (r *AuthorinoReconciler) ReconcileAuthorinoPermissions(ctx context.Context,
 		return err
 	}
 
-	// authorino-leader-election-role role
+	// leader election Role of the instance
 	if err := r.reconcileLeaderElectionRole(ctx, authorinoInstance); err != nil {
 		return err
 	}
 
-	// RoleBinding for the authorino-leader-election-role role
+	// RoleBinding for the leader election Role of the instance
 	if err := r.reconcileLeaderElectionRoleBinding(ctx, authorinoInstance); err != nil {
 		return err
 	}
@@ -153,6 +262,17 @@ func (r *AuthorinoReconciler) ReconcileAuthorinoPermissions(ctx context.Context,
 	// binding.
 	r.cleanupLegacyClusterRoleBindings(ctx, authorinoInstance)
 
+	// Best-effort migration: remove the leader election Role shared by the
+	// instances of the namespace, once none of them binds it anymore
+	r.cleanupLegacyLeaderElectionRole(ctx, authorinoInstance)
+
+	// the ClusterRoles and extra permissions not allowed by the operator configuration were left out, which fails the
+	// instance until its spec is fixed or the operator configuration allows them
+	if err := rbacNotAllowed(authorinoInstance); err != nil {
+		logger, _ := logr.FromContext(ctx)
+		return r.WrapErrorWithStatusUpdate(logger, authorinoInstance, r.SetStatusFailed(statusRBACNotAllowed), err)
+	}
+
 	return nil
 }
 
@@ -206,69 +326,181 @@ func (r *AuthorinoReconciler) cleanupLegacyClusterRoleBindings(ctx context.Conte
 	}
 }
 
+// cleanupLegacyLeaderElectionRole deletes the leader election Role shared by
+// the Authorino instances of the namespace (authorino-leader-election-role),
+// from before each instance got its own, once no RoleBinding of the namespace
+// binds it, i.e. all the instances were moved to their own Roles. The shared
+// Role is controlled by whichever instance created it, so until then, deleting
+// that instance garbage-collects the Role and breaks the leader election of the
+// others, which get their own Role as soon as they are reconciled.
+// It is best-effort, as cleanupLegacyClusterRoleBindings. Only a Role
+// controlled by an Authorino instance, i.e. created by the operator, is deleted.
+func (r *AuthorinoReconciler) cleanupLegacyLeaderElectionRole(ctx context.Context, authorinoInstance *api.Authorino) {
+	logger, _ := logr.FromContext(ctx)
+
+	role := &k8srbac.Role{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Namespace: authorinoInstance.Namespace, Name: AuthorinoLeaderElectionRoleName}, role); err != nil {
+		if !errors.IsNotFound(err) {
+			logger.Error(err, "failed to get legacy leader election Role", "name", AuthorinoLeaderElectionRoleName)
+		}
+		return
+	}
+
+	if owner := k8smeta.GetControllerOf(role); owner == nil || owner.APIVersion != api.GroupVersion.String() || owner.Kind != "Authorino" {
+		return
+	}
+
+	bindingList := &k8srbac.RoleBindingList{}
+	if err := r.Client.List(ctx, bindingList, client.InNamespace(authorinoInstance.Namespace)); err != nil {
+		logger.Error(err, "failed to list the RoleBindings of the legacy leader election Role", "name", AuthorinoLeaderElectionRoleName)
+		return
+	}
+	for _, binding := range bindingList.Items {
+		if binding.RoleRef.Kind == "Role" && binding.RoleRef.Name == role.Name {
+			return
+		}
+	}
+
+	preconditions := client.Preconditions{
+		UID:             &role.UID,
+		ResourceVersion: &role.ResourceVersion,
+	}
+	if err := r.Client.Delete(ctx, role, preconditions); err != nil && !errors.IsNotFound(err) {
+		logger.Error(err, "failed to delete legacy leader election Role", "name", AuthorinoLeaderElectionRoleName)
+	}
+}
+
+// ManagerClusterRoleName returns the name of the ClusterRole that grants Authorino the permissions to watch
+// AuthConfigs and Secrets, as set in the spec of the Authorino instance, if one of the allowedClusterRoles of the
+// operator configuration, or else in the operator configuration
+func ManagerClusterRoleName(authorino *api.Authorino) string {
+	if authorino != nil && authorino.Spec.RBAC != nil && config.Current().ClusterRoleAllowed(authorino.Spec.RBAC.ManagerClusterRole) {
+		return authorino.Spec.RBAC.ManagerClusterRole
+	}
+	if name := config.Current().ClusterRoles.Manager; name != "" {
+		return name
+	}
+	return AuthorinoManagerClusterRoleName
+}
+
+// K8sAuthClusterRoleName returns the name of the ClusterRole that grants Authorino the permissions to create
+// TokenReviews and SubjectAccessReviews, as set in the spec of the Authorino instance, if one of the
+// allowedClusterRoles of the operator configuration, or else in the operator configuration
+func K8sAuthClusterRoleName(authorino *api.Authorino) string {
+	if authorino != nil && authorino.Spec.RBAC != nil && config.Current().ClusterRoleAllowed(authorino.Spec.RBAC.K8sAuthClusterRole) {
+		return authorino.Spec.RBAC.K8sAuthClusterRole
+	}
+	if name := config.Current().ClusterRoles.K8sAuth; name != "" {
+		return name
+	}
+	return AuthorinoK8sAuthClusterRoleName
+}
+
 func (r *AuthorinoReconciler) reconcileManagerClusterRoleBinding(ctx context.Context, authorinoInstance *api.Authorino) error {
-	clusterRoleKey := client.ObjectKey{Name: AuthorinoManagerClusterRoleName}
+	clusterRoleKey := client.ObjectKey{Name: ManagerClusterRoleName(authorinoInstance)}
 	if err := r.checkClusterRoleExists(ctx, clusterRoleKey, authorinoInstance); err != nil {
 		return err
 	}
 
-	sa := authorinoResources.GetAuthorinoServiceAccount(authorinoInstance.Namespace, authorinoInstance.Name, authorinoInstance.Labels)
-
 	// if cluster scoped, ensure service account is in the binding
-	if authorinoInstance.Spec.ClusterWide {
-		binding := authorinoResources.GetAuthorinoClusterRoleBinding(authorinoInstance.Namespace, authorinoInstance.Name, AuthorinoManagerClusterRoleBindingName, AuthorinoManagerClusterRoleName, sa, authorinoInstance.Labels)
+	binding := managerClusterRoleBinding(authorinoInstance)
+	if !IsObjectTaggedToDelete(binding) {
 		return r.reconcileClusterRoleBinding(ctx, binding, authorinoInstance)
 	}
 
 	// local namespace scope
 	// if switching from cluster-wide to namespaced, delete the ClusterRoleBinding
-	binding := authorinoResources.GetAuthorinoClusterRoleBinding(authorinoInstance.Namespace, authorinoInstance.Name, AuthorinoManagerClusterRoleBindingName, AuthorinoManagerClusterRoleName, sa, authorinoInstance.Labels)
-	TagObjectToDelete(binding)
 	r.reconcileClusterRoleBinding(ctx, binding, authorinoInstance)
 
 	return nil
 }
 
+// managerClusterRoleBinding builds the ClusterRoleBinding for the authorino-manager-role cluster role, tagged to delete
+// unless the Authorino instance is cluster-wide
+func managerClusterRoleBinding(authorinoInstance *api.Authorino) *k8srbac.ClusterRoleBinding {
+	sa := authorinoResources.GetAuthorinoServiceAccount(authorinoInstance.Namespace, authorinoInstance.Name, authorinoInstance.Labels)
+	binding := authorinoResources.GetAuthorinoClusterRoleBinding(authorinoInstance.Namespace, authorinoInstance.Name, AuthorinoManagerClusterRoleBindingName, ManagerClusterRoleName(authorinoInstance), sa, authorinoInstance.Labels)
+	if !authorinoInstance.Spec.ClusterWide {
+		TagObjectToDelete(binding)
+	}
+	return binding
+}
+
 func (r *AuthorinoReconciler) reconcileManagerRoleBinding(ctx context.Context, authorinoInstance *api.Authorino) error {
-	clusterRoleKey := client.ObjectKey{Name: AuthorinoManagerClusterRoleName}
+	clusterRoleKey := client.ObjectKey{Name: ManagerClusterRoleName(authorinoInstance)}
 	if err := r.checkClusterRoleExists(ctx, clusterRoleKey, authorinoInstance); err != nil {
 		return err
 	}
 
-	sa := authorinoResources.GetAuthorinoServiceAccount(authorinoInstance.Namespace, authorinoInstance.Name, authorinoInstance.Labels)
-
-	binding := authorinoResources.GetAuthorinoRoleBinding(authorinoInstance.Namespace, authorinoInstance.Name, AuthorinoManagerClusterRoleBindingName, "ClusterRole", AuthorinoManagerClusterRoleName, sa, authorinoInstance.Labels)
+	return r.reconcileRoleBinding(ctx, managerRoleBinding(authorinoInstance), authorinoInstance)
+}
 
+// managerRoleBinding builds the RoleBinding for the authorino-manager-role cluster role in the namespace of the
+// Authorino instance, tagged to delete when the scope is granted by the cluster-wide binding
+func managerRoleBinding(authorinoInstance *api.Authorino) *k8srbac.RoleBinding {
+	sa := authorinoResources.GetAuthorinoServiceAccount(authorinoInstance.Namespace, authorinoInstance.Name, authorinoInstance.Labels)
+	binding := authorinoResources.GetAuthorinoRoleBinding(authorinoInstance.Namespace, authorinoInstance.Name, AuthorinoManagerClusterRoleBindingName, "ClusterRole", ManagerClusterRoleName(authorinoInstance), sa, authorinoInstance.Labels)
 	if authorinoInstance.Spec.ClusterWide {
 		TagObjectToDelete(binding)
 	}
+	return binding
+}
 
-	return r.reconcileRoleBinding(ctx, binding, authorinoInstance)
+// ReconcileAuthorinoKubernetesAuthPermissions grants the Authorino instance the permissions for kubernetes auth, which
+// depend on the AuthConfigs it watches when kubernetes auth is Auto
+func (r *AuthorinoReconciler) ReconcileAuthorinoKubernetesAuthPermissions(ctx context.Context, authorinoInstance *api.Authorino) error {
+	return r.reconcileManagerAuthClusterRoleBinding(ctx, authorinoInstance)
 }
 
 func (r *AuthorinoReconciler) reconcileManagerAuthClusterRoleBinding(ctx context.Context, authorinoInstance *api.Authorino) error {
-	clusterRoleKey := client.ObjectKey{Name: AuthorinoK8sAuthClusterRoleName}
-	if err := r.checkClusterRoleExists(ctx, clusterRoleKey, authorinoInstance); err != nil {
+	logger, err := logr.FromContext(ctx)
+	if err != nil {
 		return err
 	}
 
-	sa := authorinoResources.GetAuthorinoServiceAccount(authorinoInstance.Namespace, authorinoInstance.Name, authorinoInstance.Labels)
+	binding := k8sAuthClusterRoleBinding(authorinoInstance)
+	if !IsObjectTaggedToDelete(binding) && kubernetesAuth(authorinoInstance) == api.KubernetesAuthAuto {
+		used, err := r.kubernetesAuthUsed(ctx, authorinoInstance)
+		if err != nil {
+			return r.WrapErrorWithStatusUpdate(logger, authorinoInstance, r.SetStatusFailed(statusUnableToGetAuthConfigs),
+				fmt.Errorf("failed to list the AuthConfigs of %s, err: %v", authorinoInstance.Name, err))
+		}
+		if !used {
+			TagObjectToDelete(binding)
+		}
+	}
+
+	// not granted, the cluster role may not even exist
+	if IsObjectTaggedToDelete(binding) {
+		return r.reconcileClusterRoleBinding(ctx, binding, authorinoInstance)
+	}
+
+	clusterRoleKey := client.ObjectKey{Name: K8sAuthClusterRoleName(authorinoInstance)}
+	if err := r.checkClusterRoleExists(ctx, clusterRoleKey, authorinoInstance); err != nil {
+		return err
+	}
 
-	binding := authorinoResources.GetAuthorinoClusterRoleBinding(authorinoInstance.Namespace, authorinoInstance.Name, AuthorinoK8sAuthClusterRoleBindingName, AuthorinoK8sAuthClusterRoleName, sa, authorinoInstance.Labels)
 	return r.reconcileClusterRoleBinding(ctx, binding, authorinoInstance)
 }
 
+// k8sAuthClusterRoleBinding builds the ClusterRoleBinding for the authorino-manager-k8s-auth-role cluster role, tagged
+// to delete when kubernetes auth is disabled for the Authorino instance
+func k8sAuthClusterRoleBinding(authorinoInstance *api.Authorino) *k8srbac.ClusterRoleBinding {
+	sa := authorinoResources.GetAuthorinoServiceAccount(authorinoInstance.Namespace, authorinoInstance.Name, authorinoInstance.Labels)
+	binding := authorinoResources.GetAuthorinoClusterRoleBinding(authorinoInstance.Namespace, authorinoInstance.Name, AuthorinoK8sAuthClusterRoleBindingName, K8sAuthClusterRoleName(authorinoInstance), sa, authorinoInstance.Labels)
+	if kubernetesAuth(authorinoInstance) == api.KubernetesAuthDisabled {
+		TagObjectToDelete(binding)
+	}
+	return binding
+}
+
 func (r *AuthorinoReconciler) reconcileLeaderElectionRole(ctx context.Context, authorinoInstance *api.Authorino) error {
 	logger, err := logr.FromContext(ctx)
 	if err != nil {
 		return err
 	}
 
-	role := &k8srbac.Role{
-		TypeMeta:   k8smeta.TypeMeta{APIVersion: k8srbac.SchemeGroupVersion.String(), Kind: "Role"},
-		ObjectMeta: k8smeta.ObjectMeta{Name: AuthorinoLeaderElectionRoleName, Namespace: authorinoInstance.Namespace},
-		Rules:      authorinoResources.GetLeaderElectionRules(),
-	}
+	role := leaderElectionRole(authorinoInstance)
 
 	if err := ctrl.SetControllerReference(authorinoInstance, role, r.Scheme); err != nil {
 		return err
@@ -283,33 +515,47 @@ func (r *AuthorinoReconciler) reconcileLeaderElectionRole(ctx context.Context, a
 			)
 		}
 
-		// With create only mutator, update not happening
-
 		if crud == "create" {
 			return r.WrapErrorWithStatusUpdate(
 				logger, authorinoInstance, r.SetStatusFailed(statusUnableToCreateLeaderElectionRole),
 				fmt.Errorf("failed to create %s role, err: %v", role.Name, err),
 			)
 		}
+
+		if crud == "update" {
+			return r.WrapErrorWithStatusUpdate(
+				logger, authorinoInstance, r.SetStatusFailed(statusUnableToUpdateLeaderElectionRole),
+				fmt.Errorf("failed to update %s role, err: %v", role.Name, err),
+			)
+		}
 	}
 
 	return nil
 }
 
+// leaderElectionRole builds the leader election Role of the Authorino instance, named after the instance, so every
+// instance of a namespace controls its own Role and gets the rules of the running operator
+func leaderElectionRole(authorinoInstance *api.Authorino) *k8srbac.Role {
+	return authorinoResources.GetAuthorinoRole(authorinoInstance.Namespace, authorinoInstance.Name, AuthorinoLeaderElectionRoleNameSuffix, authorinoResources.GetLeaderElectionRules(), authorinoInstance.Labels)
+}
+
 func (r *AuthorinoReconciler) reconcileLeaderElectionRoleBinding(ctx context.Context, authorinoInstance *api.Authorino) error {
+	return r.reconcileRoleBinding(ctx, leaderElectionRoleBinding(authorinoInstance), authorinoInstance)
+}
+
+// leaderElectionRoleBinding builds the RoleBinding for the leader election Role of the Authorino instance
+func leaderElectionRoleBinding(authorinoInstance *api.Authorino) *k8srbac.RoleBinding {
 	sa := authorinoResources.GetAuthorinoServiceAccount(authorinoInstance.Namespace, authorinoInstance.Name, authorinoInstance.Labels)
 
-	binding := authorinoResources.GetAuthorinoRoleBinding(
+	return authorinoResources.GetAuthorinoRoleBinding(
 		authorinoInstance.Namespace,
 		authorinoInstance.Name,
 		authorinoLeaderElectionRoleBindingName,
 		"Role",
-		AuthorinoLeaderElectionRoleName,
+		leaderElectionRole(authorinoInstance).Name,
 		sa,
 		authorinoInstance.Labels,
 	)
-
-	return r.reconcileRoleBinding(ctx, binding, authorinoInstance)
 }
 
 func (r *AuthorinoReconciler) checkClusterRoleExists(ctx context.Context, key client.ObjectKey, authorino *api.Authorino) error {
@@ -321,7 +567,7 @@ func (r *AuthorinoReconciler) checkClusterRoleExists(ctx context.Context, key cl
 	clusterRole := &k8srbac.ClusterRole{}
 	if err := r.Client.Get(ctx, key, clusterRole); err != nil {
 		if errors.IsNotFound(err) {
-			return r.WrapErrorWithStatusUpdate(logger, authorino, r.SetStatusFailed(statusClusterRoleNotFound), fmt.Errorf("failed to find authorino ClusterRole %s: %v", key, err))
+			return r.WrapErrorWithStatusUpdate(logger, authorino, r.SetStatusFailed(statusClusterRoleNotFound), fmt.Errorf("failed to find authorino ClusterRole %s, create it or set another one in spec.rbac: %v", key, err))
 		} else {
 			return r.WrapErrorWithStatusUpdate(logger, authorino, r.SetStatusFailed(statusUnableToGetClusterRole), fmt.Errorf("failed to get authorino ClusterRole %s: %v", key, err))
 		}
@@ -358,6 +604,13 @@ func (r *AuthorinoReconciler) reconcileResource(ctx context.Context, obj, desire
 	}
 
 	// Apply the desired state using Server-Side Apply
+	if report := driftReportFromContext(ctx); report != nil {
+		if err := r.applyResourceReportingDrift(ctx, obj, desired, report); err != nil {
+			return "update", desired, err
+		}
+		return "", obj, nil
+	}
+
 	if err := r.ApplyResource(ctx, desired); err != nil {
 		return "update", desired, err
 	}
@@ -365,6 +618,27 @@ func (r *AuthorinoReconciler) reconcileResource(ctx context.Context, obj, desire
 	return "", obj, nil
 }
 
+// RequeueAfter returns when the Authorino instance is due to be reconciled again even if nothing changes, i.e. the
+// earliest deadline of its canary, of the rollout of its Deployment, or of the drift reported. Zero means no requeue.
+func RequeueAfter(authorino *api.Authorino) time.Duration {
+	var after time.Duration
+	for _, deadline := range []time.Duration{CanaryRequeueAfter(authorino), RolloutRequeueAfter(authorino), DriftRequeueAfter(authorino)} {
+		if deadline > 0 && (after == 0 || deadline < after) {
+			after = deadline
+		}
+	}
+	return after
+}
+
+// apiAvailable tells whether the API of the given kind is served by the cluster
+func (r *AuthorinoReconciler) apiAvailable(gvk schema.GroupVersionKind) (bool, error) {
+	_, err := r.Client.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+	if meta.IsNoMatchError(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
 func (r *AuthorinoReconciler) CreateResource(ctx context.Context, obj client.Object) error {
 	logger, err := logr.FromContext(ctx)
 	if err != nil {
@@ -372,7 +646,7 @@ func (r *AuthorinoReconciler) CreateResource(ctx context.Context, obj client.Obj
 	}
 
 	logger.Info("create object", "kind", strings.Replace(fmt.Sprintf("%T", obj), "*", "", 1), "name", obj.GetName(), "namespace", obj.GetNamespace())
-	return r.Client.Create(ctx, obj)
+	return r.Client.Create(ctx, obj, client.FieldOwner(fieldOwner))
 }
 
 func (r *AuthorinoReconciler) UpdateResource(ctx context.Context, obj client.Object) error {
@@ -392,7 +666,7 @@ func (r *AuthorinoReconciler) ApplyResource(ctx context.Context, obj client.Obje
 	}
 
 	logger.Info("apply object", "kind", strings.Replace(fmt.Sprintf("%T", obj), "*", "", 1), "name", obj.GetName(), "namespace", obj.GetNamespace())
-	return r.Client.Patch(ctx, obj, client.Apply, client.ForceOwnership, client.FieldOwner("authorino-operator"))
+	return r.Client.Patch(ctx, obj, client.Apply, client.ForceOwnership, client.FieldOwner(fieldOwner))
 }
 
 func (r *AuthorinoReconciler) DeleteResource(ctx context.Context, obj client.Object, options ...client.DeleteOption) error {
@@ -439,19 +713,27 @@ func (r *AuthorinoReconciler) reconcileDeployment(ctx context.Context, logger lo
 		return fmt.Errorf("failed to cast object to Deployment")
 	}
 
-	if !DeploymentAvailable(deployment) {
-		if err = r.updateStatusConditions(authorino, statusNotReady(statusDeploymentNotReady, "Authorino Deployment resource not ready")); err != nil {
-			return err
-		}
-		return nil
+	ready := deploymentReadyCondition(deployment)
+	if rolledBack, message := deploymentRolledBack(authorino); rolledBack {
+		ready = statusNotReady(statusRolledBack, message)
 	}
-
-	if err = r.updateStatusConditions(authorino, statusReady()); err != nil {
+	if err = r.updateStatusConditions(authorino, ready); err != nil {
 		return err
 	}
 	return nil
 }
 
+// deploymentReadyCondition builds the Ready condition of the Authorino instance out of the status of its Deployment
+func deploymentReadyCondition(deployment *k8sapps.Deployment) api.Condition {
+	if failed, message := DeploymentRolloutFailed(deployment); failed {
+		return statusNotReady(statusRolloutFailed, fmt.Sprintf("Authorino Deployment rollout failed: %s", message))
+	}
+	if !DeploymentAvailable(deployment) {
+		return statusNotReady(statusDeploymentNotReady, "Authorino Deployment resource not ready")
+	}
+	return statusReady()
+}
+
 func (r *AuthorinoReconciler) reconcileService(ctx context.Context, desired *k8score.Service, authorino *api.Authorino) error {
 	logger, err := logr.FromContext(ctx)
 	if err != nil {
@@ -492,6 +774,10 @@ func (r *AuthorinoReconciler) reconcileRoleBinding(ctx context.Context, desired
 		return err
 	}
 
+	if err := r.deleteStaleBinding(ctx, desired); err != nil {
+		return r.clusterRoleStatus(logger, authorino, "update", desired.Name, err)
+	}
+
 	crud, _, err := r.reconcileResource(ctx, &k8srbac.RoleBinding{}, desired)
 
 	if err = r.clusterRoleStatus(logger, authorino, crud, desired.Name, err); err != nil {
@@ -507,6 +793,10 @@ func (r *AuthorinoReconciler) reconcileClusterRoleBinding(ctx context.Context, d
 		return err
 	}
 
+	if err := r.deleteStaleBinding(ctx, desired); err != nil {
+		return r.clusterRoleStatus(logger, authorino, "update", desired.Name, err)
+	}
+
 	crud, _, err := r.reconcileResource(ctx, &k8srbac.ClusterRoleBinding{}, desired)
 
 	if err = r.clusterRoleStatus(logger, authorino, crud, desired.Name, err); err != nil {
@@ -516,6 +806,37 @@ func (r *AuthorinoReconciler) reconcileClusterRoleBinding(ctx context.Context, d
 	return nil
 }
 
+// deleteStaleBinding deletes the RoleBinding or ClusterRoleBinding bound to a role other than the desired one (e.g.
+// after the ClusterRole names of the operator configuration changed), so it is created anew, as the role of a binding
+// cannot be changed
+func (r *AuthorinoReconciler) deleteStaleBinding(ctx context.Context, desired client.Object) error {
+	if IsObjectTaggedToDelete(desired) {
+		return nil
+	}
+
+	var existing client.Object
+	var existingRoleRef func() k8srbac.RoleRef
+	var desiredRoleRef k8srbac.RoleRef
+	switch binding := desired.(type) {
+	case *k8srbac.RoleBinding:
+		live := &k8srbac.RoleBinding{}
+		existing, existingRoleRef, desiredRoleRef = live, func() k8srbac.RoleRef { return live.RoleRef }, binding.RoleRef
+	case *k8srbac.ClusterRoleBinding:
+		live := &k8srbac.ClusterRoleBinding{}
+		existing, existingRoleRef, desiredRoleRef = live, func() k8srbac.RoleRef { return live.RoleRef }, binding.RoleRef
+	default:
+		return nil
+	}
+
+	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(desired), existing); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if existingRoleRef() == desiredRoleRef {
+		return nil
+	}
+	return client.IgnoreNotFound(r.DeleteResource(ctx, existing))
+}
+
 func (r *AuthorinoReconciler) clusterRoleStatus(logger logr.Logger, authorino *api.Authorino, crud, name string, err error) error {
 	if crud == "read" && err != nil {
 		return r.WrapErrorWithStatusUpdate(
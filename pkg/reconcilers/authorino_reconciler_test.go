@@ -2,22 +2,39 @@ package reconcilers
 
 import (
 	"context"
+	"fmt"
+	"reflect"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	k8score "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	k8srbac "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/meta/testrestmapper"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	api "github.com/kuadrant/authorino-operator/api/v1beta1"
+	"github.com/kuadrant/authorino-operator/pkg/config"
 	authorinoResources "github.com/kuadrant/authorino-operator/pkg/resources"
 )
 
@@ -47,6 +64,14 @@ var authorinoInstance = &api.Authorino{
 func setupTestEnvironment(t *testing.T, objs []client.Object) (*AuthorinoReconciler, context.Context) {
 	t.Helper()
 
+	return setupTestEnvironmentWithAPIs(t, objs)
+}
+
+// setupTestEnvironmentWithAPIs sets up the test environment with a client that also serves the given APIs (e.g.
+// AuthConfig) as unstructured namespaced kinds
+func setupTestEnvironmentWithAPIs(t *testing.T, objs []client.Object, gvks ...schema.GroupVersionKind) (*AuthorinoReconciler, context.Context) {
+	t.Helper()
+
 	logger := zap.New(zap.UseDevMode(true))
 	ctx := log.IntoContext(context.Background(), logger)
 
@@ -58,7 +83,17 @@ func setupTestEnvironment(t *testing.T, objs []client.Object) (*AuthorinoReconci
 		t.Fatal(err)
 	}
 
-	cl := fake.NewClientBuilder().WithScheme(s).WithStatusSubresource(&api.Authorino{}, &appsv1.Deployment{}).WithObjects(objs...).Build()
+	builder := fake.NewClientBuilder().WithScheme(s).WithStatusSubresource(&api.Authorino{}, &appsv1.Deployment{}).WithReturnManagedFields().WithObjects(objs...)
+	if len(gvks) > 0 {
+		extraAPIs := meta.NewDefaultRESTMapper(nil)
+		for _, gvk := range gvks {
+			if !s.Recognizes(gvk) { // the fake client registers the unstructured kinds in the scheme
+				extraAPIs.Add(gvk, meta.RESTScopeNamespace)
+			}
+		}
+		builder = builder.WithRESTMapper(meta.MultiRESTMapper{testrestmapper.TestOnlyStaticRESTMapper(s), extraAPIs})
+	}
+	cl := builder.Build()
 
 	return &AuthorinoReconciler{
 		Client: cl,
@@ -87,6 +122,50 @@ func getArgValue(args []string, flag string) string {
 	return ""
 }
 
+// authorinoFlags are the flags of Authorino
+var authorinoFlags = []string{
+	FlagWatchNamespace, FlagWatchedAuthConfigLabelSelector, FlagWatchedSecretLabelSelector, FlagSupersedingHostSubsets,
+	FlagLogLevel, FlagLogMode, FlagTimeout, FlagExtAuthGRPCPort, FlagExtAuthHTTPPort, FlagTlsCertPath, FlagTlsCertKeyPath,
+	FlagOidcHTTPPort, FlagOidcTLSCertPath, FlagOidcTLSCertKeyPath, FlagEvaluatorCacheSize, FlagTracingServiceEndpoint,
+	FlagTracingServiceTag, FlagTracingServiceInsecure, FlagDeepMetricsEnabled, FlagMetricsAddr, FlagHealthProbeAddr,
+	FlagEnableLeaderElection, FlagMaxHttpRequestBodySize, FlagTlsMinVersion, FlagTlsMaxVersion, FlagTlsCipherSuites,
+	FlagOidcTlsMinVersion, FlagOidcTlsMaxVersion, FlagOidcTlsCipherSuites,
+}
+
+// TestAuthorinoDeploymentFlags checks that the args of Authorino only have flags of Authorino, with every setting of
+// the spec, as Authorino fails to start on unknown flags
+func TestAuthorinoDeploymentFlags(t *testing.T) {
+	a := authorinoInstance.DeepCopy()
+	a.Spec.LeaderElection = &api.LeaderElection{
+		Enabled:       pointer.Bool(true),
+		LeaseDuration: &metav1.Duration{Duration: 30 * time.Second},
+		RenewDeadline: &metav1.Duration{Duration: 20 * time.Second},
+		RetryPeriod:   &metav1.Duration{Duration: 5 * time.Second},
+		LeaseLockName: "test-authorino-leader",
+	}
+	a.Spec.LogLevel = "debug"
+	a.Spec.LogMode = "production"
+	a.Spec.Logging = &api.Logging{Encoder: "json", TimeFormat: "rfc3339", Sampling: &api.LogSampling{Initial: 10, Thereafter: 100}}
+	a.Spec.Listener.Tls = api.Tls{Enabled: pointer.Bool(false)}
+	a.Spec.OIDCServer.Tls = api.Tls{Enabled: pointer.Bool(false)}
+	a.Spec.Tracing = api.Tracing{
+		Endpoint: "rpc://otel-collector:4317",
+		Tags:     map[string]string{"env": "prod"},
+		Insecure: true,
+		Tls:      &api.TracingTls{CASecret: &k8score.LocalObjectReference{Name: "otel-ca"}},
+		Sampling: &api.TracingSampling{Ratio: "0.1"},
+	}
+
+	for _, image := range []string{"quay.io/kuadrant/authorino:latest", "quay.io/kuadrant/authorino:main", "quay.io/kuadrant/authorino:v0.21.0"} {
+		a.Spec.Image = image
+		for _, arg := range AuthorinoDeployment(a).Spec.Template.Spec.Containers[0].Args {
+			if !slices.Contains(authorinoFlags, argFlag(arg)) {
+				t.Errorf("unexpected arg %s with image %s, not a flag of Authorino", arg, image)
+			}
+		}
+	}
+}
+
 func TestBuildAuthorinoArgs(t *testing.T) {
 	t.Run("TLS disabled omits all TLS flags", func(t *testing.T) {
 		a := &api.Authorino{
@@ -197,6 +276,50 @@ func TestBuildAuthorinoArgs(t *testing.T) {
 		}
 	})
 
+	t.Run("services disabled keep the listeners", func(t *testing.T) {
+		a := authorinoInstance.DeepCopy()
+		a.Spec.Listener = api.Listener{
+			Ports:   api.Ports{GRPC: pointer.Int32(50001), HTTP: pointer.Int32(5002)},
+			Tls:     api.Tls{Enabled: pointer.Bool(true), CertSecret: &k8score.LocalObjectReference{Name: "authorino-server-cert"}},
+			Service: api.ServiceOptions{Enabled: pointer.Bool(false)},
+		}
+		a.Spec.OIDCServer = api.OIDCServer{
+			Port:    pointer.Int32(8083),
+			Tls:     api.Tls{Enabled: pointer.Bool(true), MinVersion: "1.3", CertSecret: &k8score.LocalObjectReference{Name: "authorino-oidc-server-cert"}},
+			Service: api.ServiceOptions{Enabled: pointer.Bool(false)},
+		}
+		a.Spec.Metrics = api.Metrics{
+			Port:    pointer.Int32(9090),
+			Service: api.ServiceOptions{Enabled: pointer.Bool(false)},
+		}
+		podSpec := AuthorinoDeployment(a).Spec.Template.Spec
+		args := podSpec.Containers[0].Args
+
+		for flag, expected := range map[string]string{
+			FlagExtAuthGRPCPort:    "50001",
+			FlagExtAuthHTTPPort:    "5002",
+			FlagTlsCertPath:        DefaultTlsCertPath,
+			FlagOidcHTTPPort:       "8083",
+			FlagOidcTLSCertPath:    DefaultOidcTlsCertPath,
+			FlagOidcTLSCertKeyPath: DefaultOidcTlsCertKeyPath,
+			FlagOidcTlsMinVersion:  "1.3",
+			FlagMetricsAddr:        ":9090",
+		} {
+			if v := getArgValue(args, flag); v != expected {
+				t.Errorf("expected --%s=%s with the Services disabled, got %q", flag, expected, v)
+			}
+		}
+		volumes := map[string]string{}
+		for _, volume := range podSpec.Volumes {
+			if volume.Secret != nil {
+				volumes[volume.Name] = volume.Secret.SecretName
+			}
+		}
+		if volumes[AuthorinoTlsCertVolumeName] != "authorino-server-cert" || volumes[AuthorinoOidcTlsCertVolumeName] != "authorino-oidc-server-cert" {
+			t.Errorf("expected the TLS certificates mounted with the Services disabled, got %v", volumes)
+		}
+	})
+
 	t.Run("empty version and cipher fields are omitted", func(t *testing.T) {
 		a := &api.Authorino{
 			Spec: api.AuthorinoSpec{
@@ -223,228 +346,2929 @@ func TestBuildAuthorinoArgs(t *testing.T) {
 			}
 		}
 	})
-}
 
-func TestReconcileService(t *testing.T) {
-	t.Run("update existing service", func(t *testing.T) {
-		existingService := &k8score.Service{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      "test-authorino",
-				Namespace: namespace,
-			},
-			TypeMeta: metav1.TypeMeta{
-				Kind:       "Service",
-				APIVersion: k8score.SchemeGroupVersion.String(),
+	t.Run("tracing TLS, sampling and headers", func(t *testing.T) {
+		a := authorinoInstance.DeepCopy()
+		a.Spec.Tracing = api.Tracing{
+			Endpoint: "rpc://otel-collector.observability:4317",
+			Tags:     map[string]string{"env": "prod", "cluster": "eu-1"},
+			Tls: &api.TracingTls{
+				CASecret:         &k8score.LocalObjectReference{Name: "otel-ca"},
+				ClientCertSecret: &k8score.LocalObjectReference{Name: "otel-client"},
 			},
-			Spec: k8score.ServiceSpec{
-				Selector: map[string]string{"old-label": "old-value"},
+			Sampling: &api.TracingSampling{Ratio: "0.1", ParentBased: pointer.Bool(true)},
+			Headers: []api.TracingHeader{
+				{Name: "Authorization", ValueFrom: k8score.SecretKeySelector{LocalObjectReference: k8score.LocalObjectReference{Name: "otel-auth"}, Key: "token"}},
 			},
 		}
+		args := buildAuthorinoArgs(a)
 
-		r, ctx := setupTestEnvironment(t, []client.Object{authorinoInstance, existingService})
-
-		newLabels := map[string]string{"new-label": "new-value"}
-		desiredService := existingService.DeepCopy()
-		desiredService.Spec.Selector = newLabels
-		desiredService.Labels = newLabels
-		err := r.reconcileService(ctx, desiredService, authorinoInstance)
-		if err != nil {
-			t.Fatalf("unexpected error: %v", err)
+		var tags []string
+		for _, arg := range args {
+			if strings.HasPrefix(arg, "--"+FlagTracingServiceTag+"=") {
+				tags = append(tags, arg)
+			}
+		}
+		if expected := []string{"--tracing-service-tag=cluster=eu-1", "--tracing-service-tag=env=prod"}; !reflect.DeepEqual(tags, expected) {
+			t.Errorf("expected tags %v in order, got %v", expected, tags)
 		}
 
-		updated := &k8score.Service{}
-		err = r.Client.Get(ctx, client.ObjectKeyFromObject(desiredService), updated)
-		if err != nil {
-			t.Fatalf("expected service to exist: %v", err)
+		podSpec := AuthorinoDeployment(a).Spec.Template.Spec
+		secrets := map[string]string{}
+		for _, volume := range podSpec.Volumes {
+			if volume.Secret != nil {
+				secrets[volume.Name] = volume.Secret.SecretName
+			}
+		}
+		if secrets[AuthorinoTracingCACertVolumeName] != "otel-ca" || secrets[AuthorinoTracingClientCertVolumeName] != "otel-client" {
+			t.Errorf("expected the tracing TLS Secrets to be mounted, got volumes %v", secrets)
+		}
+		mounts := map[string]string{}
+		for _, mount := range podSpec.Containers[0].VolumeMounts {
+			mounts[mount.MountPath] = mount.SubPath
+		}
+		for path, key := range map[string]string{DefaultTracingCACertPath: "ca.crt", DefaultTracingCertPath: "tls.crt", DefaultTracingCertKeyPath: "tls.key"} {
+			if mounts[path] != key {
+				t.Errorf("expected %s mounted at %s, got %v", key, path, mounts)
+			}
+		}
+		env := podSpec.Containers[0].Env
+		values := map[string]string{}
+		for _, e := range env {
+			values[e.Name] = e.Value
 		}
+		for name, expected := range map[string]string{
+			EnvOtelExporterTracesCertificate:       DefaultTracingCACertPath,
+			EnvOtelExporterTracesClientCertificate: DefaultTracingCertPath,
+			EnvOtelExporterTracesClientKey:         DefaultTracingCertKeyPath,
+			EnvOtelTracesSampler:                   "parentbased_traceidratio",
+			EnvOtelTracesSamplerArg:                "0.1",
+			EnvOtelExporterTracesHeaders:           "Authorization=$(TRACING_SERVICE_HEADER_0)",
+		} {
+			if values[name] != expected {
+				t.Errorf("expected env %s=%s, got %q", name, expected, values[name])
+			}
+		}
+		if i := slices.IndexFunc(env, func(e k8score.EnvVar) bool { return e.Name == "TRACING_SERVICE_HEADER_0" }); i < 0 || env[i].ValueFrom == nil || env[i].ValueFrom.SecretKeyRef.Name != "otel-auth" || env[i].ValueFrom.SecretKeyRef.Key != "token" {
+			t.Errorf("expected the value of the header read from the Secret, got %+v", env)
+		} else if j := slices.IndexFunc(env, func(e k8score.EnvVar) bool { return e.Name == EnvOtelExporterTracesHeaders }); j < i {
+			t.Errorf("expected the header env var defined before %s, got %+v", EnvOtelExporterTracesHeaders, env)
+		}
+		for _, arg := range podSpec.Containers[0].Args {
+			if strings.Contains(arg, "TRACING_SERVICE_HEADER") || strings.Contains(arg, "tracing-service-s") {
+				t.Errorf("unexpected tracing arg: %s", arg)
+			}
+		}
+	})
 
-		if updated.Spec.Selector["new-label"] != "new-value" {
-			t.Errorf("expected selector 'new-label' to be 'new-value', got %s", updated.Spec.Selector["new-label"])
+	t.Run("tracing samplers", func(t *testing.T) {
+		for _, tc := range []struct {
+			sampling     api.TracingSampling
+			sampler, arg string
+		}{
+			{api.TracingSampling{Ratio: "0.5"}, "parentbased_traceidratio", "0.5"},
+			{api.TracingSampling{Ratio: "0.5", ParentBased: pointer.Bool(false)}, "traceidratio", "0.5"},
+			{api.TracingSampling{ParentBased: pointer.Bool(false)}, "always_on", ""},
+			{api.TracingSampling{}, "parentbased_always_on", ""},
+		} {
+			values := map[string]string{}
+			for _, e := range buildTracingEnv(api.Tracing{Endpoint: "rpc://otel-collector:4317", Sampling: &tc.sampling}) {
+				values[e.Name] = e.Value
+			}
+			if values[EnvOtelTracesSampler] != tc.sampler || values[EnvOtelTracesSamplerArg] != tc.arg {
+				t.Errorf("expected sampler %s with arg %q for %+v, got %v", tc.sampler, tc.arg, tc.sampling, values)
+			}
 		}
+	})
 
-		if updated.Labels["new-label"] != "new-value" {
-			t.Errorf("expected label 'new-label' to be 'new-value', got %s", updated.Labels["new-label"])
+	t.Run("tracing disabled omits tracing settings", func(t *testing.T) {
+		a := authorinoInstance.DeepCopy()
+		a.Spec.Tracing = api.Tracing{
+			Tls:      &api.TracingTls{CASecret: &k8score.LocalObjectReference{Name: "otel-ca"}},
+			Sampling: &api.TracingSampling{Ratio: "0.1"},
+		}
+		for _, arg := range buildAuthorinoArgs(a) {
+			if strings.HasPrefix(arg, "--tracing-") {
+				t.Errorf("unexpected tracing arg %s without endpoint", arg)
+			}
+		}
+		for _, volume := range AuthorinoDeployment(a).Spec.Template.Spec.Volumes {
+			if volume.Name == AuthorinoTracingCACertVolumeName {
+				t.Error("unexpected tracing CA volume without endpoint")
+			}
 		}
 	})
-}
 
-func TestReconcileDeployment(t *testing.T) {
-	t.Run("create new deployment", func(t *testing.T) {
-		r, ctx := setupTestEnvironment(t, []client.Object{authorinoInstance})
-		logger := log.FromContext(ctx)
+	t.Run("logging options", func(t *testing.T) {
+		a := authorinoInstance.DeepCopy()
+		a.Spec.LogLevel = "debug"
+		a.Spec.LogMode = "production"
+		a.Spec.Logging = &api.Logging{
+			Encoder:    "console",
+			TimeFormat: "rfc3339nano",
+			Sampling:   &api.LogSampling{Initial: 10, Thereafter: 100},
+		}
+		args := buildAuthorinoArgs(a)
+		for flag, expected := range map[string]string{
+			FlagLogLevel:              "debug",
+			FlagLogMode:               "production",
+			FlagLogEncoder:            "console",
+			FlagLogTimeFormat:         "rfc3339nano",
+			FlagLogSamplingInitial:    "10",
+			FlagLogSamplingThereafter: "100",
+		} {
+			if v := getArgValue(args, flag); v != expected {
+				t.Errorf("expected --%s=%s, got %q", flag, expected, v)
+			}
+		}
 
-		deployment := AuthorinoDeployment(authorinoInstance)
-		err := r.reconcileDeployment(ctx, logger, deployment, authorinoInstance)
-		if err != nil {
-			t.Fatalf("unexpected error: %v", err)
+		// no Authorino release takes the logging options yet
+		for _, image := range []string{"quay.io/kuadrant/authorino:v0.20.0", "quay.io/kuadrant/authorino:v9.0.0", "quay.io/kuadrant/authorino:latest"} {
+			a.Spec.Image = image
+			args := AuthorinoDeployment(a).Spec.Template.Spec.Containers[0].Args
+			if !hasArg(args, FlagLogLevel) || !hasArg(args, FlagLogMode) {
+				t.Errorf("expected --%s and --%s with image %s", FlagLogLevel, FlagLogMode, image)
+			}
+			for _, flag := range []string{FlagLogEncoder, FlagLogTimeFormat, FlagLogSamplingInitial, FlagLogSamplingThereafter} {
+				if hasArg(args, flag) {
+					t.Errorf("expected --%s to be absent with image %s", flag, image)
+				}
+			}
 		}
 
-		created := &appsv1.Deployment{}
-		err = r.Client.Get(ctx, client.ObjectKeyFromObject(deployment), created)
-		if err != nil {
-			t.Fatalf("expected deployment to exist: %v", err)
+		a.Spec.Logging = &api.Logging{Encoder: "json"}
+		args = buildAuthorinoArgs(a)
+		for _, flag := range []string{FlagLogTimeFormat, FlagLogSamplingInitial, FlagLogSamplingThereafter} {
+			if hasArg(args, flag) {
+				t.Errorf("expected --%s to be absent when not set, but it was present", flag)
+			}
 		}
-	})
 
-	t.Run("update existing deployment", func(t *testing.T) {
-		existingDeployment := &appsv1.Deployment{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      "test-authorino",
-				Namespace: namespace,
-			},
-			Spec: appsv1.DeploymentSpec{
-				Replicas: pointer.Int32(1),
-			},
+		// values of instances created before the log level and mode were documented are passed as is
+		a.Spec.LogLevel = "DEBUG"
+		a.Spec.LogMode = "dev"
+		args = buildAuthorinoArgs(a)
+		if getArgValue(args, FlagLogLevel) != "DEBUG" || getArgValue(args, FlagLogMode) != "dev" {
+			t.Errorf("expected the log level and mode passed as is, got %v", args)
 		}
+	})
 
-		r, ctx := setupTestEnvironment(t, []client.Object{authorinoInstance, existingDeployment})
-		logger := log.FromContext(ctx)
+	t.Run("leader election", func(t *testing.T) {
+		a := authorinoInstance.DeepCopy()
+		a.Spec.Replicas = pointer.Int32(1)
+		a.Spec.Canary = nil
+		if hasArg(buildAuthorinoArgs(a), FlagEnableLeaderElection) {
+			t.Errorf("expected --%s to be absent with a single replica", FlagEnableLeaderElection)
+		}
 
-		authorinoInstance.Spec.Replicas = pointer.Int32(3)
-		authorinoInstance.Labels = map[string]string{"test": "test1"}
-		desiredDeployment := AuthorinoDeployment(authorinoInstance)
-		err := r.reconcileDeployment(ctx, logger, desiredDeployment, authorinoInstance)
-		if err != nil {
-			t.Fatalf("unexpected error: %v", err)
+		// e.g. scaled by a HorizontalPodAutoscaler
+		a.Spec.LeaderElection = &api.LeaderElection{
+			Enabled:       pointer.Bool(true),
+			LeaseDuration: &metav1.Duration{Duration: 30 * time.Second},
+			RenewDeadline: &metav1.Duration{Duration: 20 * time.Second},
+			RetryPeriod:   &metav1.Duration{Duration: 5 * time.Second},
+			LeaseLockName: "test-authorino-leader",
+		}
+		args := buildAuthorinoArgs(a)
+		if !hasArg(args, FlagEnableLeaderElection) {
+			t.Errorf("expected --%s when force-enabled", FlagEnableLeaderElection)
+		}
+		for flag, expected := range map[string]string{
+			FlagLeaderElectionLeaseDuration: "30s",
+			FlagLeaderElectionRenewDeadline: "20s",
+			FlagLeaderElectionRetryPeriod:   "5s",
+			FlagLeaderElectionID:            "test-authorino-leader",
+		} {
+			if v := getArgValue(args, flag); v != expected {
+				t.Errorf("expected --%s=%s, got %q", flag, expected, v)
+			}
 		}
 
-		updated := &appsv1.Deployment{}
-		err = r.Client.Get(ctx, client.ObjectKeyFromObject(desiredDeployment), updated)
-		if err != nil {
-			t.Fatalf("expected deployment to exist: %v", err)
+		// no Authorino release takes the flags to tune it yet
+		for _, image := range []string{"quay.io/kuadrant/authorino:v0.20.0", "quay.io/kuadrant/authorino:v9.0.0", "quay.io/kuadrant/authorino:latest"} {
+			a.Spec.Image = image
+			args := AuthorinoDeployment(a).Spec.Template.Spec.Containers[0].Args
+			if !hasArg(args, FlagEnableLeaderElection) {
+				t.Errorf("expected --%s with image %s", FlagEnableLeaderElection, image)
+			}
+			for _, flag := range []string{FlagLeaderElectionLeaseDuration, FlagLeaderElectionRenewDeadline, FlagLeaderElectionRetryPeriod, FlagLeaderElectionID} {
+				if hasArg(args, flag) {
+					t.Errorf("expected --%s to be absent with image %s", flag, image)
+				}
+			}
 		}
 
-		if *updated.Spec.Replicas != 3 {
-			t.Errorf("expected 3 replicas after update, got %d", *updated.Spec.Replicas)
+		a.Spec.Replicas = pointer.Int32(3)
+		a.Spec.LeaderElection = &api.LeaderElection{Enabled: pointer.Bool(false), LeaseLockName: "test-authorino-leader"}
+		args = buildAuthorinoArgs(a)
+		if hasArg(args, FlagEnableLeaderElection) || hasArg(args, FlagLeaderElectionID) {
+			t.Errorf("expected no leader election flags when disabled, got %v", args)
 		}
+	})
 
-		if updated.ObjectMeta.Labels["test"] != "test1" {
-			t.Errorf("expected label 'test' to be 'test1', got %s", updated.Labels["test"])
+	t.Run("invalid tracing endpoint omits tracing settings", func(t *testing.T) {
+		a := authorinoInstance.DeepCopy()
+		a.Spec.Tracing = api.Tracing{
+			Endpoint: "otel-collector:4317",
+			Headers:  []api.TracingHeader{{Name: "Authorization", ValueFrom: k8score.SecretKeySelector{LocalObjectReference: k8score.LocalObjectReference{Name: "otel-auth"}, Key: "token"}}},
+		}
+		for _, arg := range buildAuthorinoArgs(a) {
+			if strings.HasPrefix(arg, "--tracing-") {
+				t.Errorf("unexpected tracing arg %s with invalid endpoint", arg)
+			}
+		}
+		if env := AuthorinoDeployment(a).Spec.Template.Spec.Containers[0].Env; len(env) != 0 {
+			t.Errorf("unexpected env %+v with invalid endpoint", env)
 		}
 	})
+}
 
-	t.Run("update existing deployment with no replica mutators", func(t *testing.T) {
-		existingDeployment := &appsv1.Deployment{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      "test-authorino",
-				Namespace: namespace,
-			},
-			Spec: appsv1.DeploymentSpec{
-				Replicas: pointer.Int32(1),
-			},
+func TestReconcileAuthorinoServices(t *testing.T) {
+	t.Run("disabled services are deleted", func(t *testing.T) {
+		instance := authorinoInstance.DeepCopy()
+		instance.Spec.OIDCServer.Service.Enabled = pointer.Bool(false)
+		instance.Spec.Metrics.Service.Enabled = pointer.Bool(false)
+
+		existingServices := []client.Object{
+			authorinoResources.NewOIDCService(instance.Name, instance.Namespace, DefaultOIDCServicePort, nil),
+			authorinoResources.NewMetricsService(instance.Name, instance.Namespace, DefaultMetricsServicePort, nil),
 		}
 
-		r, ctx := setupTestEnvironment(t, []client.Object{authorinoInstance, existingDeployment})
-		logger := log.FromContext(ctx)
+		r, ctx := setupTestEnvironment(t, append([]client.Object{instance}, existingServices...))
 
-		authorinoInstance.Spec.Replicas = pointer.Int32(3)
-		authorinoInstance.Labels = map[string]string{"test": "test1"}
-		desiredDeployment := AuthorinoDeployment(authorinoInstance)
-		err := r.reconcileDeployment(ctx, logger, desiredDeployment, authorinoInstance)
-		if err != nil {
+		if err := r.ReconcileAuthorinoServices(ctx, instance); err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
 
-		updated := &appsv1.Deployment{}
-		err = r.Client.Get(ctx, client.ObjectKeyFromObject(desiredDeployment), updated)
-		if err != nil {
-			t.Fatalf("expected deployment to exist: %v", err)
+		for _, service := range existingServices {
+			err := r.Client.Get(ctx, client.ObjectKeyFromObject(service), &k8score.Service{})
+			if !apierrors.IsNotFound(err) {
+				t.Errorf("expected service %q to be deleted, got err=%v", service.GetName(), err)
+			}
 		}
 
-		// With Server-Side Apply, all fields in the desired state are applied
-		// Even if ReplicasMutator is not included, replicas will be updated because
-		// they are part of the desired deployment state
-		if *updated.Spec.Replicas != *authorinoInstance.Spec.Replicas {
-			t.Errorf("expected replicas to be updated to %d, got %d", *authorinoInstance.Spec.Replicas, *updated.Spec.Replicas)
+		authService := authorinoResources.NewAuthService(instance.Name, instance.Namespace, DefaultAuthGRPCServicePort, DefaultAuthHTTPServicePort, nil)
+		if err := r.Client.Get(ctx, client.ObjectKeyFromObject(authService), &k8score.Service{}); err != nil {
+			t.Errorf("expected service %q to exist: %v", authService.Name, err)
 		}
-
 	})
+}
 
-	t.Run("deployment not ready", func(t *testing.T) {
-		existingDeployment := &appsv1.Deployment{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      "test-authorino",
-				Namespace: namespace,
-			},
-			Status: appsv1.DeploymentStatus{},
+func TestReconcileAuthorinoOIDCServerExposure(t *testing.T) {
+	t.Run("exposes the OIDC server with an Ingress when the Route API is not available", func(t *testing.T) {
+		instance := authorinoInstance.DeepCopy()
+		instance.Spec.OIDCServer.Expose = &api.OIDCServerExpose{
+			Host:         "oidc.example.com",
+			TlsSecretRef: &k8score.LocalObjectReference{Name: "oidc-public-cert"},
 		}
 
-		r, ctx := setupTestEnvironment(t, []client.Object{authorinoInstance, existingDeployment})
-		logger := log.FromContext(ctx)
+		r, ctx := setupTestEnvironment(t, []client.Object{instance})
 
-		desiredDeployment := AuthorinoDeployment(authorinoInstance)
-		err := r.reconcileDeployment(ctx, logger, desiredDeployment, authorinoInstance)
-		if err != nil {
+		if err := r.ReconcileAuthorinoOIDCServerExposure(ctx, instance); err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
 
-		updated := &api.Authorino{}
-		err = r.Client.Get(ctx, client.ObjectKeyFromObject(authorinoInstance), updated)
-		if err != nil {
-			t.Fatalf("expected deployment to exist: %v", err)
+		ingress := &networkingv1.Ingress{}
+		if err := r.Client.Get(ctx, client.ObjectKey{Namespace: instance.Namespace, Name: instance.Name + "-authorino-oidc"}, ingress); err != nil {
+			t.Fatalf("expected ingress to exist: %v", err)
+		}
+		if len(ingress.Spec.TLS) != 1 || ingress.Spec.TLS[0].SecretName != "oidc-public-cert" {
+			t.Errorf("expected ingress tls with secret oidc-public-cert, got %+v", ingress.Spec.TLS)
+		}
+		if backend := ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service; backend.Name != instance.Name+"-authorino-oidc" || backend.Port.Number != DefaultOIDCServicePort {
+			t.Errorf("expected ingress backend to be the OIDC service, got %+v", backend)
 		}
 
-		if updated.Status.Conditions[0].Type != api.ConditionReady || updated.Status.Conditions[0].Status != k8score.ConditionFalse {
-			t.Errorf("expected condition type to be %s:%s, got %s", api.ConditionReady, k8score.ConditionFalse, updated.Status.Conditions[0])
+		updated := &api.Authorino{}
+		if err := r.Client.Get(ctx, client.ObjectKeyFromObject(instance), updated); err != nil {
+			t.Fatalf("expected authorino to exist: %v", err)
+		}
+		if updated.Status.OIDCServerURL != "https://oidc.example.com" {
+			t.Errorf("expected oidc server url to be https://oidc.example.com, got %q", updated.Status.OIDCServerURL)
 		}
 	})
-}
 
-func clusterRoleBindingSubject(namespace, crName string) k8srbac.Subject {
-	sa := authorinoResources.GetAuthorinoServiceAccount(namespace, crName, nil)
-	return authorinoResources.GetSubjectForRoleBinding(sa)
-}
+	t.Run("deletes the Ingress when no longer exposed", func(t *testing.T) {
+		instance := authorinoInstance.DeepCopy()
+		instance.Status.OIDCServerURL = "http://oidc.example.com"
+		existingIngress := authorinoResources.NewOIDCIngress(instance.Name, instance.Namespace, "oidc.example.com", DefaultOIDCServicePort, nil, "", nil, nil)
 
-func TestCleanupLegacyClusterRoleBindings(t *testing.T) {
-	const suffix = AuthorinoK8sAuthClusterRoleBindingName
+		r, ctx := setupTestEnvironment(t, []client.Object{instance, existingIngress})
 
-	t.Run("deletes legacy binding owned by this instance", func(t *testing.T) {
-		instance := &api.Authorino{
-			ObjectMeta: metav1.ObjectMeta{Name: "gateway", Namespace: "team-a"},
+		if err := r.ReconcileAuthorinoOIDCServerExposure(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
 		}
-		legacyName := instance.Name + "-" + suffix
-		binding := &k8srbac.ClusterRoleBinding{
-			ObjectMeta: metav1.ObjectMeta{Name: legacyName},
-			RoleRef:    k8srbac.RoleRef{APIGroup: k8srbac.GroupName, Kind: "ClusterRole", Name: AuthorinoK8sAuthClusterRoleName},
-			Subjects:   []k8srbac.Subject{clusterRoleBindingSubject("team-a", "gateway")},
+
+		err := r.Client.Get(ctx, client.ObjectKeyFromObject(existingIngress), &networkingv1.Ingress{})
+		if !apierrors.IsNotFound(err) {
+			t.Errorf("expected ingress to be deleted, got err=%v", err)
 		}
-		r, ctx := setupTestEnvironment(t, []client.Object{binding})
+		if instance.Status.OIDCServerURL != "" {
+			t.Errorf("expected oidc server url to be cleared, got %q", instance.Status.OIDCServerURL)
+		}
+	})
 
-		r.cleanupLegacyClusterRoleBindings(ctx, instance)
+	t.Run("fails when Route is requested and the Route API is not available", func(t *testing.T) {
+		instance := authorinoInstance.DeepCopy()
+		instance.Spec.OIDCServer.Expose = &api.OIDCServerExpose{Kind: "Route"}
 
-		err := r.Client.Get(ctx, client.ObjectKey{Name: legacyName}, &k8srbac.ClusterRoleBinding{})
-		if !apierrors.IsNotFound(err) {
-			t.Fatalf("expected legacy binding %q to be deleted, got err=%v", legacyName, err)
+		r, ctx := setupTestEnvironment(t, []client.Object{instance})
+
+		if err := r.ReconcileAuthorinoOIDCServerExposure(ctx, instance); err == nil {
+			t.Fatal("expected error, got nil")
 		}
 	})
 
-	t.Run("preserves colliding legacy binding owned by another instance", func(t *testing.T) {
-		// A legacy CR named "team-a.gateway" (in namespace "team") produced a
-		// binding named "team-a.gateway-<suffix>", which is byte-identical to the
-		// new canonical name for namespace "team-a" / CR "gateway". Reconciling
-		// the "team-a.gateway" instance must not delete (or alter) a binding that
-		// belongs to the "team-a"/"gateway" instance.
-		instance := &api.Authorino{
-			ObjectMeta: metav1.ObjectMeta{Name: "team-a.gateway", Namespace: "team"},
+	t.Run("fails to expose the OIDC server with tls with an Ingress", func(t *testing.T) {
+		instance := authorinoInstance.DeepCopy()
+		instance.Spec.OIDCServer.Tls = api.Tls{CertSecret: &k8score.LocalObjectReference{Name: "oidc-cert"}}
+		instance.Spec.OIDCServer.Expose = &api.OIDCServerExpose{Host: "oidc.example.com"}
+
+		r, ctx := setupTestEnvironment(t, []client.Object{instance})
+
+		if err := r.ReconcileAuthorinoOIDCServerExposure(ctx, instance); err == nil || !strings.Contains(err.Error(), "the OIDC server serves TLS") {
+			t.Fatalf("expected error about the TLS of the OIDC server, got %v", err)
 		}
-		legacyName := instance.Name + "-" + suffix // "team-a.gateway-<suffix>"
+	})
 
-		foreignSubject := clusterRoleBindingSubject("team-a", "gateway")
-		binding := &k8srbac.ClusterRoleBinding{
-			ObjectMeta: metav1.ObjectMeta{Name: legacyName},
-			RoleRef:    k8srbac.RoleRef{APIGroup: k8srbac.GroupName, Kind: "ClusterRole", Name: AuthorinoK8sAuthClusterRoleName},
-			Subjects:   []k8srbac.Subject{foreignSubject},
+	t.Run("routes to the OIDC server according to its tls settings", func(t *testing.T) {
+		secret := &k8score.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "oidc-cert", Namespace: namespace},
+			Data:       map[string][]byte{"ca.crt": []byte("ca"), "tls.crt": []byte("cert"), "tls.key": []byte("key")},
 		}
-		r, ctx := setupTestEnvironment(t, []client.Object{binding})
+		tlsEnabled := api.Tls{CertSecret: &k8score.LocalObjectReference{Name: "oidc-cert"}}
+		tlsDisabled := api.Tls{Enabled: pointer.Bool(false)}
 
-		r.cleanupLegacyClusterRoleBindings(ctx, instance)
+		tests := []struct {
+			name        string
+			tls         api.Tls
+			termination string
+			expected    string
+			ca          string
+			err         string
+		}{
+			{name: "default with tls", tls: tlsEnabled, expected: "passthrough"},
+			{name: "default without tls", tls: tlsDisabled, expected: "edge"},
+			{name: "reencrypt", tls: tlsEnabled, termination: "reencrypt", expected: "reencrypt", ca: "ca"},
+			{name: "edge with tls", tls: tlsEnabled, termination: "edge", err: "edge termination does not match"},
+			{name: "passthrough without tls", tls: tlsDisabled, termination: "passthrough", err: "passthrough termination does not match"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				instance := authorinoInstance.DeepCopy()
+				instance.Spec.OIDCServer.Tls = tt.tls
+				instance.Spec.OIDCServer.Expose = &api.OIDCServerExpose{Kind: "Route", Termination: tt.termination}
+				r, ctx := setupTestEnvironmentWithAPIs(t, []client.Object{instance, secret}, authorinoResources.RouteGVK)
 
-		got := &k8srbac.ClusterRoleBinding{}
-		if err := r.Client.Get(ctx, client.ObjectKey{Name: legacyName}, got); err != nil {
-			t.Fatalf("expected colliding binding %q to be preserved, got err=%v", legacyName, err)
+				err := r.ReconcileAuthorinoOIDCServerExposure(ctx, instance)
+				if tt.err != "" {
+					if err == nil || !strings.Contains(err.Error(), tt.err) {
+						t.Fatalf("expected error containing %q, got %v", tt.err, err)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				route := &unstructured.Unstructured{}
+				route.SetGroupVersionKind(authorinoResources.RouteGVK)
+				if err := r.Client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: instance.Name + "-authorino-oidc"}, route); err != nil {
+					t.Fatal(err)
+				}
+				if termination, _, _ := unstructured.NestedString(route.Object, "spec", "tls", "termination"); termination != tt.expected {
+					t.Errorf("expected %s termination, got %s", tt.expected, termination)
+				}
+				if ca, _, _ := unstructured.NestedString(route.Object, "spec", "tls", "destinationCACertificate"); ca != tt.ca {
+					t.Errorf("expected destination CA certificate %q, got %q", tt.ca, ca)
+				}
+			})
 		}
-		if len(got.Subjects) != 1 || got.Subjects[0] != foreignSubject {
-			t.Errorf("expected legacy subject to be preserved, got %+v", got.Subjects)
+	})
+}
+
+func TestReconcileAuthorinoGatewayAPIIntegration(t *testing.T) {
+	t.Run("fails when the Gateway API is not available", func(t *testing.T) {
+		instance := authorinoInstance.DeepCopy()
+		instance.Spec.Integration.GatewayAPI = &api.GatewayAPIIntegration{
+			Kind:       "GRPCRoute",
+			ParentRefs: []api.GatewayAPIReference{{Name: "gateway"}},
+		}
+
+		r, ctx := setupTestEnvironment(t, []client.Object{instance})
+
+		if err := r.ReconcileAuthorinoGatewayAPIIntegration(ctx, instance); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+
+	t.Run("fails when the auth Service is disabled", func(t *testing.T) {
+		instance := authorinoInstance.DeepCopy()
+		instance.Spec.Listener.Service.Enabled = pointer.Bool(false)
+		instance.Spec.Integration.GatewayAPI = &api.GatewayAPIIntegration{
+			Kind:       "GRPCRoute",
+			ParentRefs: []api.GatewayAPIReference{{Name: "gateway"}},
+		}
+
+		r, ctx := setupTestEnvironmentWithAPIs(t, []client.Object{instance}, authorinoResources.GRPCRouteGVK)
+
+		if err := r.ReconcileAuthorinoGatewayAPIIntegration(ctx, instance); err == nil || !strings.Contains(err.Error(), "auth Service is disabled") {
+			t.Fatalf("expected error on the auth Service disabled, got %v", err)
+		}
+		var reason string
+		for _, cond := range instance.Status.Conditions {
+			if cond.Type == api.ConditionReady {
+				reason = cond.Reason
+			}
+		}
+		if reason != statusUnableToAttachToGatewayAPI {
+			t.Errorf("expected Ready condition with reason %s, got %q", statusUnableToAttachToGatewayAPI, reason)
+		}
+	})
+
+	t.Run("no-op when not configured", func(t *testing.T) {
+		instance := authorinoInstance.DeepCopy()
+
+		r, ctx := setupTestEnvironment(t, []client.Object{instance})
+
+		if err := r.ReconcileAuthorinoGatewayAPIIntegration(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	objectName := authorinoInstance.Name + "-authorino-authorization"
+	getObject := func(t *testing.T, r *AuthorinoReconciler, ctx context.Context, gvk schema.GroupVersionKind) (*unstructured.Unstructured, error) {
+		t.Helper()
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(gvk)
+		return obj, r.Client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: objectName}, obj)
+	}
+	attachedCondition := func(instance *api.Authorino) *api.Condition {
+		for i := range instance.Status.Conditions {
+			if instance.Status.Conditions[i].Type == api.ConditionGatewayAPIAttached {
+				return &instance.Status.Conditions[i]
+			}
+		}
+		return nil
+	}
+	backendRef := func(t *testing.T, obj *unstructured.Unstructured, fields ...string) map[string]interface{} {
+		t.Helper()
+		refs, _, _ := unstructured.NestedSlice(obj.Object, fields...)
+		if len(refs) != 1 {
+			t.Fatalf("expected 1 backendRef in %s, got %v", strings.Join(fields, "."), refs)
+		}
+		return refs[0].(map[string]interface{})
+	}
+
+	t.Run("generates the selected kind owned by the instance, and deletes it when disabled", func(t *testing.T) {
+		instance := authorinoInstance.DeepCopy()
+		instance.Spec.Integration.GatewayAPI = &api.GatewayAPIIntegration{
+			Kind:       "GRPCRoute",
+			ParentRefs: []api.GatewayAPIReference{{Name: "gateway", Namespace: "gateway-system", SectionName: "grpc"}},
+			Hostnames:  []string{"authorino.example.com"},
+		}
+
+		r, ctx := setupTestEnvironmentWithAPIs(t, []client.Object{instance}, gatewayAPIKinds...)
+
+		if err := r.ReconcileAuthorinoGatewayAPIIntegration(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		route, err := getObject(t, r, ctx, authorinoResources.GRPCRouteGVK)
+		if err != nil {
+			t.Fatalf("expected GRPCRoute %s: %v", objectName, err)
+		}
+		if owner := metav1.GetControllerOf(route); owner == nil || owner.Kind != "Authorino" || owner.Name != instance.Name {
+			t.Errorf("expected the GRPCRoute to be controlled by the Authorino instance, got %+v", route.GetOwnerReferences())
+		}
+		parentRefs, _, _ := unstructured.NestedSlice(route.Object, "spec", "parentRefs")
+		expectedParentRef := map[string]interface{}{"group": "gateway.networking.k8s.io", "kind": "Gateway", "name": "gateway", "namespace": "gateway-system", "sectionName": "grpc"}
+		if len(parentRefs) != 1 || !reflect.DeepEqual(parentRefs[0], expectedParentRef) {
+			t.Errorf("expected parentRefs [%v], got %v", expectedParentRef, parentRefs)
+		}
+		if hostnames, _, _ := unstructured.NestedStringSlice(route.Object, "spec", "hostnames"); !reflect.DeepEqual(hostnames, []string{"authorino.example.com"}) {
+			t.Errorf("expected hostnames [authorino.example.com], got %v", hostnames)
+		}
+		rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+		if len(rules) != 1 {
+			t.Fatalf("expected 1 rule, got %v", rules)
+		}
+		if ref := backendRef(t, &unstructured.Unstructured{Object: rules[0].(map[string]interface{})}, "backendRefs"); ref["name"] != objectName || ref["port"] != int64(DefaultAuthGRPCServicePort) {
+			t.Errorf("expected backendRef to %s:%d, got %v", objectName, DefaultAuthGRPCServicePort, ref)
+		}
+		for _, gvk := range []schema.GroupVersionKind{authorinoResources.HTTPRouteGVK, authorinoResources.SecurityPolicyGVK} {
+			if _, err := getObject(t, r, ctx, gvk); !apierrors.IsNotFound(err) {
+				t.Errorf("expected no %s, got err: %v", gvk.Kind, err)
+			}
+		}
+		if cond := attachedCondition(instance); cond == nil || cond.Status != k8score.ConditionUnknown || cond.Reason != statusGatewayAPIAttachmentPending {
+			t.Errorf("expected GatewayAPIAttached condition pending, got %+v", cond)
+		}
+
+		instance.Spec.Integration.GatewayAPI = nil
+		if err := r.ReconcileAuthorinoGatewayAPIIntegration(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := getObject(t, r, ctx, authorinoResources.GRPCRouteGVK); !apierrors.IsNotFound(err) {
+			t.Errorf("expected the GRPCRoute to be deleted, got err: %v", err)
+		}
+		if cond := attachedCondition(instance); cond != nil {
+			t.Errorf("expected no GatewayAPIAttached condition, got %+v", cond)
+		}
+	})
+
+	t.Run("replaces the object when the kind changes", func(t *testing.T) {
+		instance := authorinoInstance.DeepCopy()
+		instance.Spec.Listener.Ports.HTTP = pointer.Int32(5002)
+		instance.Spec.Integration.GatewayAPI = &api.GatewayAPIIntegration{
+			Kind:       "GRPCRoute",
+			ParentRefs: []api.GatewayAPIReference{{Name: "gateway"}},
+		}
+
+		r, ctx := setupTestEnvironmentWithAPIs(t, []client.Object{instance}, gatewayAPIKinds...)
+
+		if err := r.ReconcileAuthorinoGatewayAPIIntegration(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		instance.Spec.Integration.GatewayAPI.Kind = "HTTPRoute"
+		if err := r.ReconcileAuthorinoGatewayAPIIntegration(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if _, err := getObject(t, r, ctx, authorinoResources.GRPCRouteGVK); !apierrors.IsNotFound(err) {
+			t.Errorf("expected the GRPCRoute to be deleted, got err: %v", err)
+		}
+		route, err := getObject(t, r, ctx, authorinoResources.HTTPRouteGVK)
+		if err != nil {
+			t.Fatalf("expected HTTPRoute %s: %v", objectName, err)
+		}
+		if owner := metav1.GetControllerOf(route); owner == nil || owner.Name != instance.Name {
+			t.Errorf("expected the HTTPRoute to be controlled by the Authorino instance, got %+v", route.GetOwnerReferences())
+		}
+		rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+		if len(rules) != 1 {
+			t.Fatalf("expected 1 rule, got %v", rules)
+		}
+		if ref := backendRef(t, &unstructured.Unstructured{Object: rules[0].(map[string]interface{})}, "backendRefs"); ref["port"] != int64(5002) {
+			t.Errorf("expected backendRef to the HTTP port 5002, got %v", ref)
+		}
+	})
+
+	t.Run("generates an Envoy Gateway SecurityPolicy", func(t *testing.T) {
+		instance := authorinoInstance.DeepCopy()
+		instance.Spec.Integration.GatewayAPI = &api.GatewayAPIIntegration{
+			Kind:       "SecurityPolicy",
+			TargetRefs: []api.GatewayAPIReference{{Kind: "HTTPRoute", Name: "api"}},
+		}
+
+		r, ctx := setupTestEnvironmentWithAPIs(t, []client.Object{instance}, gatewayAPIKinds...)
+
+		if err := r.ReconcileAuthorinoGatewayAPIIntegration(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		policy, err := getObject(t, r, ctx, authorinoResources.SecurityPolicyGVK)
+		if err != nil {
+			t.Fatalf("expected SecurityPolicy %s: %v", objectName, err)
+		}
+		if owner := metav1.GetControllerOf(policy); owner == nil || owner.Name != instance.Name {
+			t.Errorf("expected the SecurityPolicy to be controlled by the Authorino instance, got %+v", policy.GetOwnerReferences())
+		}
+		targetRefs, _, _ := unstructured.NestedSlice(policy.Object, "spec", "targetRefs")
+		expectedTargetRef := map[string]interface{}{"group": "gateway.networking.k8s.io", "kind": "HTTPRoute", "name": "api"}
+		if len(targetRefs) != 1 || !reflect.DeepEqual(targetRefs[0], expectedTargetRef) {
+			t.Errorf("expected targetRefs [%v], got %v", expectedTargetRef, targetRefs)
+		}
+		if ref := backendRef(t, policy, "spec", "extAuth", "grpc", "backendRefs"); ref["name"] != objectName || ref["port"] != int64(DefaultAuthGRPCServicePort) {
+			t.Errorf("expected ext_authz backendRef to %s:%d, got %v", objectName, DefaultAuthGRPCServicePort, ref)
+		}
+		for _, gvk := range []schema.GroupVersionKind{authorinoResources.GRPCRouteGVK, authorinoResources.HTTPRouteGVK} {
+			if _, err := getObject(t, r, ctx, gvk); !apierrors.IsNotFound(err) {
+				t.Errorf("expected no %s, got err: %v", gvk.Kind, err)
+			}
+		}
+
+		instance.Spec.Integration.GatewayAPI = nil
+		if err := r.ReconcileAuthorinoGatewayAPIIntegration(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := getObject(t, r, ctx, authorinoResources.SecurityPolicyGVK); !apierrors.IsNotFound(err) {
+			t.Errorf("expected the SecurityPolicy to be deleted, got err: %v", err)
+		}
+	})
+}
+
+func TestOperatorConfig(t *testing.T) {
+	t.Cleanup(func() { config.Set(nil) })
+
+	t.Run("default image and resources", func(t *testing.T) {
+		instance := authorinoInstance.DeepCopy()
+		instance.Spec.Image = ""
+		config.Set(&config.OperatorConfig{
+			DefaultAuthorinoImage: "quay.io/kuadrant/authorino:v0.20.0",
+			DefaultResources:      &k8score.ResourceRequirements{Requests: k8score.ResourceList{k8score.ResourceCPU: resource.MustParse("100m")}},
+		})
+
+		container := AuthorinoDeployment(instance).Spec.Template.Spec.Containers[0]
+		if container.Image != "quay.io/kuadrant/authorino:v0.20.0" {
+			t.Errorf("expected the default image of the operator configuration, got %s", container.Image)
+		}
+		if cpu := container.Resources.Requests.Cpu().String(); cpu != "100m" {
+			t.Errorf("expected the default resources of the operator configuration, got cpu request %s", cpu)
+		}
+
+		instance.Spec.Image = "quay.io/kuadrant/authorino:custom"
+		if image := AuthorinoImage(instance); image != "quay.io/kuadrant/authorino:custom" {
+			t.Errorf("expected the image of the spec to take precedence, got %s", image)
+		}
+	})
+
+	t.Run("cluster role names", func(t *testing.T) {
+		instance := authorinoInstance.DeepCopy()
+		clusterRole := func(name string) *k8srbac.ClusterRole {
+			return &k8srbac.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		}
+		r, ctx := setupTestEnvironment(t, []client.Object{instance, clusterRole(AuthorinoManagerClusterRoleName), clusterRole("custom-manager-role")})
+
+		config.Set(nil)
+		if err := r.reconcileManagerRoleBinding(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var deleted []string
+		r.Client = interceptor.NewClient(r.Client.(client.WithWatch), interceptor.Funcs{
+			Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+				deleted = append(deleted, obj.GetName())
+				return c.Delete(ctx, obj, opts...)
+			},
+		})
+
+		// the role of a binding cannot be changed, so the binding is replaced
+		config.Set(&config.OperatorConfig{ClusterRoles: config.ClusterRoles{Manager: "custom-manager-role"}})
+		if err := r.reconcileManagerRoleBinding(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		binding := &k8srbac.RoleBinding{}
+		if err := r.Client.Get(ctx, client.ObjectKeyFromObject(managerRoleBinding(instance)), binding); err != nil {
+			t.Fatal(err)
+		}
+		if binding.RoleRef.Name != "custom-manager-role" {
+			t.Errorf("expected the RoleBinding to bind custom-manager-role, got %s", binding.RoleRef.Name)
+		}
+		if !slices.Equal(deleted, []string{binding.Name}) {
+			t.Errorf("expected the RoleBinding bound to the previous ClusterRole to be deleted, got deleted %v", deleted)
+		}
+		if name := K8sAuthClusterRoleName(instance); name != AuthorinoK8sAuthClusterRoleName {
+			t.Errorf("expected the default k8s-auth ClusterRole name, got %s", name)
+		}
+	})
+
+	t.Run("watch scopes", func(t *testing.T) {
+		instance := authorinoInstance.DeepCopy()
+		if scope := WatchScope(instance); scope != config.ScopeNamespaced {
+			t.Errorf("expected Namespaced scope, got %s", scope)
+		}
+		instance.Spec.ClusterWide = true
+		if scope := WatchScope(instance); scope != config.ScopeClusterWide {
+			t.Errorf("expected ClusterWide scope, got %s", scope)
+		}
+	})
+}
+
+func TestRBAC(t *testing.T) {
+	t.Cleanup(func() { config.Set(nil) })
+
+	rules := []k8srbac.PolicyRule{{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"get", "list", "watch"}}}
+	exists := func(t *testing.T, r *AuthorinoReconciler, ctx context.Context, obj client.Object) bool {
+		t.Helper()
+		err := r.Client.Get(ctx, client.ObjectKeyFromObject(obj), obj)
+		if err != nil && !apierrors.IsNotFound(err) {
+			t.Fatal(err)
+		}
+		return err == nil
+	}
+
+	t.Run("cluster role names", func(t *testing.T) {
+		instance := authorinoInstance.DeepCopy()
+		instance.Spec.RBAC = &api.RBAC{ManagerClusterRole: "tenant-manager-role"}
+		config.Set(&config.OperatorConfig{ClusterRoles: config.ClusterRoles{Manager: "custom-manager-role", K8sAuth: "custom-k8s-auth-role"}})
+
+		// not allowed by the operator configuration
+		if name := ManagerClusterRoleName(instance); name != "custom-manager-role" {
+			t.Errorf("expected the manager ClusterRole of the operator configuration, got %s", name)
+		}
+		if err := rbacNotAllowed(instance); err == nil || !strings.Contains(err.Error(), "tenant-manager-role") {
+			t.Errorf("expected error on the tenant-manager-role ClusterRole not allowed, got %v", err)
+		}
+
+		config.Set(&config.OperatorConfig{
+			ClusterRoles:        config.ClusterRoles{Manager: "custom-manager-role", K8sAuth: "custom-k8s-auth-role"},
+			AllowedClusterRoles: []string{"tenant-manager-role"},
+		})
+		if name := ManagerClusterRoleName(instance); name != "tenant-manager-role" {
+			t.Errorf("expected the allowed manager ClusterRole of the spec to take precedence, got %s", name)
+		}
+		if name := K8sAuthClusterRoleName(instance); name != "custom-k8s-auth-role" {
+			t.Errorf("expected the k8s-auth ClusterRole of the operator configuration, got %s", name)
+		}
+		if err := rbacNotAllowed(instance); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		r, ctx := setupTestEnvironment(t, []client.Object{instance})
+		err := r.reconcileManagerRoleBinding(ctx, instance)
+		if err == nil || !strings.Contains(err.Error(), "tenant-manager-role") || !strings.Contains(err.Error(), "spec.rbac") {
+			t.Errorf("expected error on missing tenant-manager-role ClusterRole, got %v", err)
+		}
+	})
+
+	t.Run("extra rules not allowed", func(t *testing.T) {
+		config.Set(&config.OperatorConfig{AllowedRules: []k8srbac.PolicyRule{{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"get"}}}})
+		instance := authorinoInstance.DeepCopy()
+		instance.Spec.RBAC = &api.RBAC{Rules: rules}
+		r, ctx := setupTestEnvironment(t, []client.Object{instance})
+
+		if err := r.reconcileRBACRules(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if exists(t, r, ctx, rulesRole(instance)) || exists(t, r, ctx, rulesRoleBinding(instance)) {
+			t.Errorf("expected no Role nor RoleBinding for extra rules not allowed")
+		}
+		if err := rbacNotAllowed(instance); err == nil || !strings.Contains(err.Error(), "spec.rbac.rules[0]") {
+			t.Errorf("expected error on the extra rules not allowed, got %v", err)
+		}
+	})
+
+	t.Run("extra rules follow the watch scope", func(t *testing.T) {
+		config.Set(&config.OperatorConfig{AllowedRules: rules})
+		instance := authorinoInstance.DeepCopy()
+		instance.Spec.RBAC = &api.RBAC{Rules: rules}
+		r, ctx := setupTestEnvironment(t, []client.Object{instance})
+
+		// namespaced
+		if err := r.reconcileRBACRules(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		role := rulesRole(instance)
+		if !exists(t, r, ctx, role) || !reflect.DeepEqual(role.Rules, rules) {
+			t.Errorf("expected Role %s with the extra rules, got %v", role.Name, role.Rules)
+		}
+		if !metav1.IsControlledBy(role, instance) {
+			t.Errorf("expected Role %s to be owned by the Authorino instance", role.Name)
+		}
+		roleBinding := rulesRoleBinding(instance)
+		if !exists(t, r, ctx, roleBinding) || roleBinding.RoleRef.Kind != "Role" || roleBinding.RoleRef.Name != role.Name {
+			t.Errorf("expected RoleBinding %s to bind Role %s, got %+v", roleBinding.Name, role.Name, roleBinding.RoleRef)
+		}
+		if exists(t, r, ctx, rulesClusterRole(instance)) {
+			t.Errorf("expected no ClusterRole for a namespaced instance")
+		}
+
+		// cluster-wide
+		instance.Spec.ClusterWide = true
+		if err := r.reconcileRBACRules(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if exists(t, r, ctx, &k8srbac.Role{ObjectMeta: role.ObjectMeta}) || exists(t, r, ctx, &k8srbac.RoleBinding{ObjectMeta: roleBinding.ObjectMeta}) {
+			t.Errorf("expected the Role and RoleBinding to be deleted for a cluster-wide instance")
+		}
+		clusterRole := rulesClusterRole(instance)
+		if !exists(t, r, ctx, clusterRole) || !reflect.DeepEqual(clusterRole.Rules, rules) {
+			t.Errorf("expected ClusterRole %s with the extra rules, got %v", clusterRole.Name, clusterRole.Rules)
+		}
+		clusterRoleBinding := rulesClusterRoleBinding(instance)
+		if !exists(t, r, ctx, clusterRoleBinding) || clusterRoleBinding.RoleRef.Name != clusterRole.Name {
+			t.Errorf("expected ClusterRoleBinding %s to bind ClusterRole %s, got %+v", clusterRoleBinding.Name, clusterRole.Name, clusterRoleBinding.RoleRef)
+		}
+
+		// no extra rules
+		instance.Spec.RBAC = nil
+		if err := r.reconcileRBACRules(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if exists(t, r, ctx, &k8srbac.ClusterRole{ObjectMeta: clusterRole.ObjectMeta}) || exists(t, r, ctx, &k8srbac.ClusterRoleBinding{ObjectMeta: clusterRoleBinding.ObjectMeta}) {
+			t.Errorf("expected the ClusterRole and ClusterRoleBinding to be deleted without extra rules")
+		}
+	})
+
+	t.Run("kubernetes auth", func(t *testing.T) {
+		config.Set(nil)
+		authConfig := &unstructured.Unstructured{}
+		authConfig.SetGroupVersionKind(authorinoResources.AuthConfigGVK)
+		authConfig.SetNamespace(namespace)
+		authConfig.SetName("api-key")
+		_ = unstructured.SetNestedMap(authConfig.Object, map[string]interface{}{"api-key": map[string]interface{}{"apiKey": map[string]interface{}{}}}, "spec", "authentication")
+
+		instance := authorinoInstance.DeepCopy()
+		instance.Spec.RBAC = &api.RBAC{KubernetesAuth: api.KubernetesAuthAuto}
+		// no k8s-auth ClusterRole needed while not bound
+		r, ctx := setupTestEnvironmentWithAPIs(t, []client.Object{instance, authConfig}, authorinoResources.AuthConfigGVK)
+
+		binding := k8sAuthClusterRoleBinding(instance)
+		if err := r.reconcileManagerAuthClusterRoleBinding(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if exists(t, r, ctx, binding) {
+			t.Errorf("expected no k8s-auth ClusterRoleBinding while no AuthConfig uses kubernetes auth")
+		}
+
+		if err := r.Client.Create(ctx, &k8srbac.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: AuthorinoK8sAuthClusterRoleName}}); err != nil {
+			t.Fatal(err)
+		}
+		_ = unstructured.SetNestedMap(authConfig.Object, map[string]interface{}{"k8s": map[string]interface{}{"kubernetesTokenReview": map[string]interface{}{}}}, "spec", "authentication")
+		if err := r.Client.Update(ctx, authConfig); err != nil {
+			t.Fatal(err)
+		}
+		if err := r.reconcileManagerAuthClusterRoleBinding(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !exists(t, r, ctx, binding) {
+			t.Errorf("expected the k8s-auth ClusterRoleBinding once an AuthConfig uses kubernetes auth")
+		}
+
+		instance.Spec.RBAC.KubernetesAuth = api.KubernetesAuthDisabled
+		if err := r.reconcileManagerAuthClusterRoleBinding(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if exists(t, r, ctx, &k8srbac.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: binding.Name}}) {
+			t.Errorf("expected the k8s-auth ClusterRoleBinding to be deleted with kubernetes auth disabled")
+		}
+	})
+}
+
+func TestReconcileLeaderElectionRole(t *testing.T) {
+	instance := authorinoInstance.DeepCopy()
+	other := authorinoInstance.DeepCopy()
+	other.Name = "other-authorino"
+
+	stale := leaderElectionRole(instance)
+	stale.Rules = []k8srbac.PolicyRule{{APIGroups: []string{"coordination.k8s.io"}, Resources: []string{"leases"}, Verbs: []string{"get"}}}
+	staleBinding := leaderElectionRoleBinding(instance)
+	staleBinding.RoleRef.Name = AuthorinoLeaderElectionRoleName
+	r, ctx := setupTestEnvironment(t, []client.Object{instance, other, stale, staleBinding})
+
+	for _, a := range []*api.Authorino{instance, other} {
+		if err := r.reconcileLeaderElectionRole(ctx, a); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := r.reconcileLeaderElectionRoleBinding(ctx, a); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	for _, a := range []*api.Authorino{instance, other} {
+		role := &k8srbac.Role{}
+		if err := r.Client.Get(ctx, client.ObjectKeyFromObject(leaderElectionRole(a)), role); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(role.Rules, authorinoResources.GetLeaderElectionRules()) {
+			t.Errorf("expected the leader election Role of %s with the current rules, got %v", a.Name, role.Rules)
+		}
+		if !metav1.IsControlledBy(role, a) {
+			t.Errorf("expected the leader election Role %s to be controlled by %s", role.Name, a.Name)
+		}
+		binding := &k8srbac.RoleBinding{}
+		if err := r.Client.Get(ctx, client.ObjectKeyFromObject(leaderElectionRoleBinding(a)), binding); err != nil {
+			t.Fatal(err)
+		}
+		if binding.RoleRef.Name != role.Name {
+			t.Errorf("expected the leader election RoleBinding of %s to bind %s, got %s", a.Name, role.Name, binding.RoleRef.Name)
+		}
+	}
+}
+
+func TestAuthConfigLabelSelectors(t *testing.T) {
+	instance := authorinoInstance.DeepCopy()
+	if got := AuthConfigLabelSelectors(instance); got != "" {
+		t.Errorf("expected no selectors, got %q", got)
+	}
+
+	instance.Spec.Sharding = &api.Sharding{Group: "edge", Shards: 3, Index: 1}
+	if got := AuthConfigLabelSelectors(instance); got != "shard.operator.authorino.kuadrant.io/edge=1" {
+		t.Errorf("expected the shard selector, got %q", got)
+	}
+
+	instance.Spec.AuthConfigLabelSelectors = "team=a"
+	if got := getArgValue(buildAuthorinoArgs(instance), FlagWatchedAuthConfigLabelSelector); got != "team=a,shard.operator.authorino.kuadrant.io/edge=1" {
+		t.Errorf("expected the user selectors narrowed to the shard, got %q", got)
+	}
+}
+
+func TestReconcileAuthorinoSharding(t *testing.T) {
+	newAuthConfig := func(namespace, name string, labels map[string]string) *unstructured.Unstructured {
+		authConfig := &unstructured.Unstructured{}
+		authConfig.SetGroupVersionKind(authorinoResources.AuthConfigGVK)
+		authConfig.SetNamespace(namespace)
+		authConfig.SetName(name)
+		authConfig.SetLabels(labels)
+		return authConfig
+	}
+
+	t.Run("labels the AuthConfigs and counts them per shard", func(t *testing.T) {
+		instance := authorinoInstance.DeepCopy()
+		instance.Spec.ClusterWide = true
+		instance.Spec.AuthConfigLabelSelectors = "team=a"
+		instance.Spec.Sharding = &api.Sharding{Group: "edge", Shards: 2, Index: 0, Strategy: "Hash"}
+
+		objs := []client.Object{instance, newAuthConfig("other", "excluded", map[string]string{"team": "b"})}
+		for _, name := range []string{"a", "b", "c", "d", "e", "f"} {
+			objs = append(objs, newAuthConfig("ns-"+name, name, map[string]string{"team": "a"}))
+		}
+		r, ctx := setupTestEnvironmentWithAPIs(t, objs, authorinoResources.AuthConfigGVK)
+
+		if err := r.ReconcileAuthorinoSharding(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		shardLabel := authorinoResources.ShardLabel("edge")
+		counts := map[string]int32{}
+		for _, name := range []string{"a", "b", "c", "d", "e", "f"} {
+			authConfig := newAuthConfig("ns-"+name, name, nil)
+			if err := r.Client.Get(ctx, client.ObjectKeyFromObject(authConfig), authConfig); err != nil {
+				t.Fatal(err)
+			}
+			shard, ok := authConfig.GetLabels()[shardLabel]
+			if !ok {
+				t.Errorf("expected AuthConfig %s to be assigned to a shard", name)
+			}
+			if expected := fmt.Sprint(authorinoResources.AuthConfigShard("ns-"+name, name, 2, false)); shard != expected {
+				t.Errorf("expected AuthConfig %s in shard %s, got %s", name, expected, shard)
+			}
+			if authConfig.GetLabels()["team"] != "a" {
+				t.Errorf("expected the other labels of AuthConfig %s to be preserved", name)
+			}
+			counts[shard]++
+		}
+
+		excluded := newAuthConfig("other", "excluded", nil)
+		if err := r.Client.Get(ctx, client.ObjectKeyFromObject(excluded), excluded); err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := excluded.GetLabels()[shardLabel]; ok {
+			t.Error("expected AuthConfig not matching the selectors not to be assigned to a shard")
+		}
+
+		if len(instance.Status.Shards) != 2 {
+			t.Fatalf("expected 2 shards in the status, got %+v", instance.Status.Shards)
+		}
+		for _, shard := range instance.Status.Shards {
+			if shard.AuthConfigs != counts[fmt.Sprint(shard.Index)] {
+				t.Errorf("expected %d AuthConfigs in shard %d, got %d", counts[fmt.Sprint(shard.Index)], shard.Index, shard.AuthConfigs)
+			}
+		}
+	})
+
+	t.Run("namespace strategy keeps the AuthConfigs of a namespace together", func(t *testing.T) {
+		instance := authorinoInstance.DeepCopy()
+		instance.Spec.Sharding = &api.Sharding{Group: "edge", Shards: 4, Index: 0, Strategy: "Namespace"}
+
+		r, ctx := setupTestEnvironmentWithAPIs(t, []client.Object{
+			instance,
+			newAuthConfig(namespace, "one", nil),
+			newAuthConfig(namespace, "two", nil),
+			newAuthConfig(namespace, "three", nil),
+		}, authorinoResources.AuthConfigGVK)
+
+		if err := r.ReconcileAuthorinoSharding(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		shard := authorinoResources.AuthConfigShard(namespace, "", 4, true)
+		for _, s := range instance.Status.Shards {
+			expected := int32(0)
+			if s.Index == shard {
+				expected = 3
+			}
+			if s.AuthConfigs != expected {
+				t.Errorf("expected %d AuthConfigs in shard %d, got %d", expected, s.Index, s.AuthConfigs)
+			}
+		}
+	})
+
+	t.Run("lists the AuthConfigs once per reconciliation", func(t *testing.T) {
+		instance := authorinoInstance.DeepCopy()
+		instance.Spec.Sharding = &api.Sharding{Group: "edge", Shards: 2, Index: 0, Strategy: "Hash"}
+
+		objs := []client.Object{instance}
+		for _, name := range []string{"a", "b", "c", "d"} {
+			objs = append(objs, newAuthConfig(namespace, name, nil))
+		}
+		r, ctx := setupTestEnvironmentWithAPIs(t, objs, authorinoResources.AuthConfigGVK)
+		var lists int
+		r.Client = interceptor.NewClient(r.Client.(client.WithWatch), interceptor.Funcs{
+			List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				if _, ok := list.(*unstructured.UnstructuredList); ok {
+					lists++
+				}
+				return c.List(ctx, list, opts...)
+			},
+		})
+
+		ctx = WithWatchedAuthConfigs(ctx)
+		if err := r.ReconcileAuthorinoSharding(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := r.ReconcileAuthorinoAuthConfigsStatus(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if lists != 1 {
+			t.Errorf("expected the AuthConfigs to be listed once, got %d", lists)
+		}
+		// the AuthConfigs of the shard, as labeled by the sharding
+		if total := instance.Status.AuthConfigs.Total; total != instance.Status.Shards[0].AuthConfigs {
+			t.Errorf("expected the AuthConfigs of shard 0 to be counted, got %d of %+v", total, instance.Status.Shards)
+		}
+	})
+
+	t.Run("fails when the AuthConfig API is not available", func(t *testing.T) {
+		instance := authorinoInstance.DeepCopy()
+		instance.Spec.Sharding = &api.Sharding{Group: "edge", Shards: 2}
+
+		r, ctx := setupTestEnvironment(t, []client.Object{instance})
+
+		if err := r.ReconcileAuthorinoSharding(ctx, instance); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}
+
+func TestReconcileAuthorinoAuthConfigsStatus(t *testing.T) {
+	newAuthConfig := func(namespace, name string, labels map[string]string, ready string) *unstructured.Unstructured {
+		authConfig := &unstructured.Unstructured{}
+		authConfig.SetGroupVersionKind(authorinoResources.AuthConfigGVK)
+		authConfig.SetNamespace(namespace)
+		authConfig.SetName(name)
+		authConfig.SetLabels(labels)
+		if ready != "" {
+			_ = unstructured.SetNestedSlice(authConfig.Object, []interface{}{
+				map[string]interface{}{"type": "Ready", "status": ready},
+			}, "status", "conditions")
+		}
+		return authConfig
+	}
+
+	t.Run("counts the ready AuthConfigs in the watch scope", func(t *testing.T) {
+		instance := authorinoInstance.DeepCopy()
+		instance.Spec.AuthConfigLabelSelectors = "team=a"
+
+		r, ctx := setupTestEnvironmentWithAPIs(t, []client.Object{
+			instance,
+			newAuthConfig(namespace, "ready", map[string]string{"team": "a"}, "True"),
+			newAuthConfig(namespace, "failing", map[string]string{"team": "a"}, "False"),
+			newAuthConfig(namespace, "pending", map[string]string{"team": "a"}, ""),
+			newAuthConfig(namespace, "other-team", map[string]string{"team": "b"}, "False"),
+			newAuthConfig("other", "other-namespace", map[string]string{"team": "a"}, "False"),
+		}, authorinoResources.AuthConfigGVK)
+
+		if err := r.ReconcileAuthorinoAuthConfigsStatus(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := &api.AuthConfigsStatus{
+			Total:    3,
+			Ready:    1,
+			NotReady: []string{namespace + "/failing", namespace + "/pending"},
+		}
+		if !reflect.DeepEqual(instance.Status.AuthConfigs, expected) {
+			t.Errorf("expected %+v, got %+v", expected, instance.Status.AuthConfigs)
+		}
+	})
+
+	t.Run("caps the names of the AuthConfigs not ready", func(t *testing.T) {
+		instance := authorinoInstance.DeepCopy()
+		objs := []client.Object{instance}
+		for i := 0; i < maxNotReadyAuthConfigs+5; i++ {
+			objs = append(objs, newAuthConfig(namespace, fmt.Sprintf("authconfig-%02d", i), nil, "False"))
+		}
+		r, ctx := setupTestEnvironmentWithAPIs(t, objs, authorinoResources.AuthConfigGVK)
+
+		if err := r.ReconcileAuthorinoAuthConfigsStatus(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		status := instance.Status.AuthConfigs
+		if status == nil || status.Total != int32(maxNotReadyAuthConfigs+5) || status.Ready != 0 {
+			t.Fatalf("unexpected status %+v", status)
+		}
+		if len(status.NotReady) != maxNotReadyAuthConfigs || status.NotReady[0] != namespace+"/authconfig-00" {
+			t.Errorf("expected the first %d AuthConfigs not ready, got %v", maxNotReadyAuthConfigs, status.NotReady)
+		}
+	})
+
+	t.Run("reports nothing without the AuthConfig API", func(t *testing.T) {
+		instance := authorinoInstance.DeepCopy()
+		instance.Status.AuthConfigs = &api.AuthConfigsStatus{Total: 1}
+
+		r, ctx := setupTestEnvironment(t, []client.Object{instance})
+
+		if err := r.ReconcileAuthorinoAuthConfigsStatus(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if instance.Status.AuthConfigs != nil {
+			t.Errorf("expected no AuthConfigs status, got %+v", instance.Status.AuthConfigs)
+		}
+	})
+}
+
+func TestAuthConfigInScope(t *testing.T) {
+	authConfig := &unstructured.Unstructured{}
+	authConfig.SetNamespace(namespace)
+	authConfig.SetLabels(map[string]string{"team": "a", authorinoResources.ShardLabel("edge"): "1"})
+
+	testCases := []struct {
+		name     string
+		mutate   func(*api.Authorino)
+		expected bool
+	}{
+		{name: "own namespace", mutate: func(a *api.Authorino) {}, expected: true},
+		{name: "other namespace", mutate: func(a *api.Authorino) { a.Namespace = "other" }, expected: false},
+		{name: "cluster-wide", mutate: func(a *api.Authorino) { a.Namespace = "other"; a.Spec.ClusterWide = true }, expected: true},
+		{name: "matching selectors", mutate: func(a *api.Authorino) { a.Spec.AuthConfigLabelSelectors = "team=a" }, expected: true},
+		{name: "not matching selectors", mutate: func(a *api.Authorino) { a.Spec.AuthConfigLabelSelectors = "team=b" }, expected: false},
+		{name: "other shard", mutate: func(a *api.Authorino) { a.Spec.Sharding = &api.Sharding{Group: "edge", Shards: 2, Index: 0} }, expected: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			instance := authorinoInstance.DeepCopy()
+			tc.mutate(instance)
+			if actual := AuthConfigInScope(instance, authConfig); actual != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestLabelSelectorsIntersect(t *testing.T) {
+	testCases := []struct {
+		name     string
+		a, b     string
+		expected bool
+	}{
+		{name: "empty selectors", a: "", b: "", expected: true},
+		{name: "empty and any", a: "", b: "team=a", expected: true},
+		{name: "different keys", a: "team=a", b: "env=prod", expected: true},
+		{name: "same key same value", a: "team=a", b: "team in (a,b)", expected: true},
+		{name: "same key different values", a: "team=a", b: "team=b", expected: false},
+		{name: "disjoint sets", a: "team in (a,b)", b: "team in (c,d)", expected: false},
+		{name: "equality and inequality", a: "team=a", b: "team!=a", expected: false},
+		{name: "inequalities", a: "team!=a", b: "team notin (b,c)", expected: true},
+		{name: "exists and does not exist", a: "team", b: "!team", expected: false},
+		{name: "does not exist and inequality", a: "!team", b: "team!=a", expected: true},
+		{name: "numeric bounds", a: "tier>1", b: "tier<3", expected: true},
+		{name: "disjoint numeric bounds", a: "tier>2", b: "tier<3", expected: false},
+		{name: "numeric bounds exhausted by exclusions", a: "tier>1,tier<4", b: "tier notin (2,3)", expected: false},
+		{name: "value out of numeric bounds", a: "tier=5", b: "tier<3", expected: false},
+		{name: "shards", a: "shard.operator.authorino.kuadrant.io/edge=0", b: "shard.operator.authorino.kuadrant.io/edge=1", expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := labelSelectorsIntersect(tc.a, tc.b)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.expected {
+				t.Errorf("expected %q and %q to intersect: %v, got %v", tc.a, tc.b, tc.expected, got)
+			}
+		})
+	}
+
+	if _, err := labelSelectorsIntersect("team in (", ""); err == nil {
+		t.Error("expected error for an invalid selector, got nil")
+	}
+}
+
+func TestReconcileAuthorinoScopeOverlap(t *testing.T) {
+	newInstance := func(namespace, name string, mutate func(*api.Authorino)) *api.Authorino {
+		instance := authorinoInstance.DeepCopy()
+		instance.Namespace = namespace
+		instance.Name = name
+		if mutate != nil {
+			mutate(instance)
+		}
+		return instance
+	}
+	conflictCondition := func(instance *api.Authorino) *api.Condition {
+		for i := range instance.Status.Conditions {
+			if instance.Status.Conditions[i].Type == api.ConditionConflict {
+				return &instance.Status.Conditions[i]
+			}
+		}
+		return nil
+	}
+
+	t.Run("cluster-wide instances overlap", func(t *testing.T) {
+		instance := newInstance("ns-a", "authorino", func(a *api.Authorino) { a.Spec.ClusterWide = true })
+		other := newInstance("ns-b", "authorino", func(a *api.Authorino) { a.Spec.ClusterWide = true })
+		namespaced := newInstance("ns-c", "authorino", func(a *api.Authorino) { a.Spec.AuthConfigLabelSelectors = "team=c" })
+
+		r, ctx := setupTestEnvironment(t, []client.Object{instance, other, namespaced})
+		recorder := events.NewFakeRecorder(10)
+		r.Recorder = recorder
+
+		if err := r.ReconcileAuthorinoScopeOverlap(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		cond := conflictCondition(instance)
+		if cond == nil || cond.Status != k8score.ConditionTrue {
+			t.Fatalf("expected Conflict condition to be true, got %+v", cond)
+		}
+		if cond.Message != "watch scope overlaps with: ns-b/authorino, ns-c/authorino" {
+			t.Errorf("unexpected Conflict message: %s", cond.Message)
+		}
+		select {
+		case event := <-recorder.Events:
+			if !strings.HasPrefix(event, "Warning ScopeOverlap") {
+				t.Errorf("expected a Warning event, got %s", event)
+			}
+		default:
+			t.Error("expected a Warning event, got none")
+		}
+
+		// no new event while the overlap is unchanged
+		if err := r.ReconcileAuthorinoScopeOverlap(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(recorder.Events) != 0 {
+			t.Errorf("expected no repeated event, got %d", len(recorder.Events))
+		}
+	})
+
+	t.Run("instances of the same namespace with disjoint selectors or shards do not overlap", func(t *testing.T) {
+		instance := newInstance(namespace, "shard-0", func(a *api.Authorino) {
+			a.Spec.Sharding = &api.Sharding{Group: "edge", Shards: 2, Index: 0}
+		})
+		otherShard := newInstance(namespace, "shard-1", func(a *api.Authorino) {
+			a.Spec.Sharding = &api.Sharding{Group: "edge", Shards: 2, Index: 1}
+		})
+		otherNamespace := newInstance("elsewhere", "authorino", nil)
+
+		r, ctx := setupTestEnvironment(t, []client.Object{instance, otherShard, otherNamespace})
+
+		if err := r.ReconcileAuthorinoScopeOverlap(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if cond := conflictCondition(instance); cond == nil || cond.Status != k8score.ConditionFalse {
+			t.Errorf("expected Conflict condition to be false, got %+v", cond)
+		}
+	})
+}
+
+func TestManagementState(t *testing.T) {
+	statusCondition := func(instance *api.Authorino, conditionType api.ConditionType) *api.Condition {
+		for i := range instance.Status.Conditions {
+			if instance.Status.Conditions[i].Type == conditionType {
+				return &instance.Status.Conditions[i]
+			}
+		}
+		return nil
+	}
+
+	t.Run("reports the management state in the Managed condition", func(t *testing.T) {
+		for state, expected := range map[string]k8score.ConditionStatus{
+			"":                           k8score.ConditionTrue,
+			api.ManagementStateManaged:   k8score.ConditionTrue,
+			api.ManagementStateUnmanaged: k8score.ConditionFalse,
+			api.ManagementStateRemoved:   k8score.ConditionFalse,
+		} {
+			instance := authorinoInstance.DeepCopy()
+			instance.Spec.ManagementState = state
+			r, ctx := setupTestEnvironment(t, []client.Object{instance})
+
+			if err := r.ReconcileAuthorinoManagementState(ctx, instance); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if cond := statusCondition(instance, api.ConditionManaged); cond == nil || cond.Status != expected {
+				t.Errorf("expected Managed condition %s for state %q, got %+v", expected, state, cond)
+			}
+		}
+	})
+
+	t.Run("reports the readiness of the Deployment without reconciling it", func(t *testing.T) {
+		instance := authorinoInstance.DeepCopy()
+		instance.Spec.ManagementState = api.ManagementStateUnmanaged
+
+		// hand-edited Deployment
+		deployment := AuthorinoDeployment(instance)
+		deployment.Spec.Replicas = pointer.Int32(5)
+		deployment.Status.Conditions = []appsv1.DeploymentCondition{{Type: appsv1.DeploymentAvailable, Status: k8score.ConditionTrue}}
+
+		r, ctx := setupTestEnvironment(t, []client.Object{instance, deployment})
+
+		if err := r.ReportAuthorinoDeploymentStatus(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cond := statusCondition(instance, api.ConditionReady); cond == nil || cond.Status != k8score.ConditionTrue {
+			t.Errorf("expected Ready condition to be true, got %+v", cond)
+		}
+
+		existing := &appsv1.Deployment{}
+		if err := r.Client.Get(ctx, client.ObjectKeyFromObject(deployment), existing); err != nil {
+			t.Fatal(err)
+		}
+		if *existing.Spec.Replicas != 5 {
+			t.Errorf("expected the hand-edited Deployment to be left untouched, got %d replicas", *existing.Spec.Replicas)
+		}
+
+		if err := r.Client.Delete(ctx, existing); err != nil {
+			t.Fatal(err)
+		}
+		if err := r.ReportAuthorinoDeploymentStatus(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cond := statusCondition(instance, api.ConditionReady); cond == nil || cond.Status != k8score.ConditionFalse {
+			t.Errorf("expected Ready condition to be false without the Deployment, got %+v", cond)
+		}
+	})
+
+	t.Run("removes the resources controlled by the instance", func(t *testing.T) {
+		instance := authorinoInstance.DeepCopy()
+		instance.UID = "test-uid"
+		instance.Spec.ManagementState = api.ManagementStateRemoved
+
+		controlled := func(obj client.Object) client.Object {
+			obj.SetOwnerReferences([]metav1.OwnerReference{{
+				APIVersion: api.GroupVersion.String(),
+				Kind:       "Authorino",
+				Name:       instance.Name,
+				UID:        instance.UID,
+				Controller: pointer.Bool(true),
+			}})
+			return obj
+		}
+		deployment := controlled(AuthorinoDeployment(instance))
+		service := controlled(&k8score.Service{ObjectMeta: metav1.ObjectMeta{Name: "test-authorino-authorino-authorization", Namespace: namespace}})
+		serviceAccount := controlled(&k8score.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "test-authorino-authorino", Namespace: namespace}})
+		unrelated := &k8score.Service{ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: namespace}}
+
+		r, ctx := setupTestEnvironment(t, []client.Object{instance, deployment, service, serviceAccount, unrelated})
+
+		if err := r.RemoveAuthorinoManagedResources(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		for _, obj := range []client.Object{deployment, service, serviceAccount} {
+			if err := r.Client.Get(ctx, client.ObjectKeyFromObject(obj), obj.DeepCopyObject().(client.Object)); !apierrors.IsNotFound(err) {
+				t.Errorf("expected %T %s to be removed, got %v", obj, obj.GetName(), err)
+			}
+		}
+		if err := r.Client.Get(ctx, client.ObjectKeyFromObject(unrelated), &k8score.Service{}); err != nil {
+			t.Errorf("expected the Service not controlled by the instance to be kept, got %v", err)
+		}
+		if err := r.Client.Get(ctx, client.ObjectKeyFromObject(instance), &api.Authorino{}); err != nil {
+			t.Errorf("expected the Authorino CR to be kept, got %v", err)
+		}
+
+		cond := statusCondition(instance, api.ConditionReady)
+		if cond == nil || cond.Status != k8score.ConditionFalse || cond.Reason != statusRemoved {
+			t.Errorf("expected Ready condition false with reason %s, got %+v", statusRemoved, cond)
+		}
+	})
+}
+
+func TestReconcileAuthorinoIstioIntegration(t *testing.T) {
+	meshConfigMap := func() *k8score.ConfigMap {
+		return &k8score.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: DefaultIstioMeshConfigMapName, Namespace: DefaultIstioMeshConfigMapNamespace},
+			Data:       map[string]string{"mesh": "accessLogFile: /dev/stdout\n"},
+		}
+	}
+
+	t.Run("registers and then removes the extension provider", func(t *testing.T) {
+		instance := authorinoInstance.DeepCopy()
+		instance.Spec.Integration.Istio = &api.IstioIntegration{}
+
+		r, ctx := setupTestEnvironment(t, []client.Object{instance, meshConfigMap()})
+
+		if err := r.ReconcileAuthorinoIstioIntegration(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		providerName := authorinoResources.IstioExtensionProviderName(instance.Namespace, instance.Name)
+		cm := &k8score.ConfigMap{}
+		if err := r.Client.Get(ctx, client.ObjectKeyFromObject(meshConfigMap()), cm); err != nil {
+			t.Fatalf("expected mesh config to exist: %v", err)
+		}
+		if !strings.Contains(cm.Data["mesh"], "name: "+providerName) {
+			t.Errorf("expected extension provider %s in the mesh config, got:\n%s", providerName, cm.Data["mesh"])
+		}
+		if p := instance.Status.IstioExtensionProvider; p == nil || p.Name != providerName {
+			t.Errorf("expected extension provider %s in the status, got %+v", providerName, p)
+		}
+
+		instance.Spec.Integration.Istio = nil
+		if err := r.ReconcileAuthorinoIstioIntegration(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := r.Client.Get(ctx, client.ObjectKeyFromObject(meshConfigMap()), cm); err != nil {
+			t.Fatalf("expected mesh config to exist: %v", err)
+		}
+		if strings.Contains(cm.Data["mesh"], providerName) {
+			t.Errorf("expected extension provider %s to be removed from the mesh config, got:\n%s", providerName, cm.Data["mesh"])
+		}
+		if instance.Status.IstioExtensionProvider != nil {
+			t.Errorf("expected no extension provider in the status, got %+v", instance.Status.IstioExtensionProvider)
+		}
+	})
+
+	t.Run("registers in the mesh config of the operator configuration and removes it on cleanup", func(t *testing.T) {
+		t.Cleanup(func() { config.Set(nil) })
+		config.Set(&config.OperatorConfig{IstioMeshConfigMap: &config.ObjectReference{Namespace: "mesh", Name: "mesh-config"}})
+
+		instance := authorinoInstance.DeepCopy()
+		instance.Spec.Integration.Istio = &api.IstioIntegration{}
+		custom := meshConfigMap()
+		custom.Namespace, custom.Name = "mesh", "mesh-config"
+
+		r, ctx := setupTestEnvironment(t, []client.Object{instance, meshConfigMap(), custom})
+
+		if err := r.ReconcileAuthorinoIstioIntegration(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := api.IstioExtensionProviderStatus{
+			Name:          authorinoResources.IstioExtensionProviderName(instance.Namespace, instance.Name),
+			MeshConfigMap: api.NamespacedObjectReference{Namespace: "mesh", Name: "mesh-config"},
+		}
+		if p := instance.Status.IstioExtensionProvider; p == nil || *p != expected {
+			t.Errorf("expected extension provider %+v in the status, got %+v", expected, p)
+		}
+		cm := &k8score.ConfigMap{}
+		if err := r.Client.Get(ctx, client.ObjectKeyFromObject(meshConfigMap()), cm); err != nil || strings.Contains(cm.Data["mesh"], expected.Name) {
+			t.Errorf("expected the default mesh config to be left untouched, got %v, err: %v", cm.Data, err)
+		}
+
+		r.CleanupIstioExtensionProvider(ctx, instance)
+		if err := r.Client.Get(ctx, client.ObjectKeyFromObject(custom), cm); err != nil || strings.Contains(cm.Data["mesh"], expected.Name) {
+			t.Errorf("expected extension provider %s to be removed from the mesh config, got %v, err: %v", expected.Name, cm.Data, err)
+		}
+		if instance.Status.IstioExtensionProvider != nil {
+			t.Errorf("expected no extension provider in the status, got %+v", instance.Status.IstioExtensionProvider)
+		}
+	})
+
+	t.Run("does not take over a provider registered for another service", func(t *testing.T) {
+		instance := authorinoInstance.DeepCopy()
+		instance.Spec.Integration.Istio = &api.IstioIntegration{}
+		providerName := authorinoResources.IstioExtensionProviderName(instance.Namespace, instance.Name)
+		mesh := meshConfigMap()
+		mesh.Data["mesh"] = "extensionProviders:\n- name: " + providerName + "\n  envoyExtAuthzGrpc:\n    service: ext-authz.istio-system.svc.cluster.local\n    port: 9000\n"
+
+		r, ctx := setupTestEnvironment(t, []client.Object{instance, mesh})
+
+		if err := r.ReconcileAuthorinoIstioIntegration(ctx, instance); err == nil || !strings.Contains(err.Error(), "already registered") {
+			t.Errorf("expected error on a provider registered for another service, got %v", err)
+		}
+		cm := &k8score.ConfigMap{}
+		if err := r.Client.Get(ctx, client.ObjectKeyFromObject(mesh), cm); err != nil || cm.Data["mesh"] != mesh.Data["mesh"] {
+			t.Errorf("expected the mesh config to be left untouched, got %v, err: %v", cm.Data, err)
+		}
+		if instance.Status.IstioExtensionProvider != nil {
+			t.Errorf("expected no extension provider in the status, got %+v", instance.Status.IstioExtensionProvider)
+		}
+	})
+
+	t.Run("fails when the auth Service is disabled", func(t *testing.T) {
+		instance := authorinoInstance.DeepCopy()
+		instance.Spec.Listener.Service.Enabled = pointer.Bool(false)
+		instance.Spec.Integration.Istio = &api.IstioIntegration{}
+
+		r, ctx := setupTestEnvironment(t, []client.Object{instance, meshConfigMap()})
+
+		if err := r.ReconcileAuthorinoIstioIntegration(ctx, instance); err == nil || !strings.Contains(err.Error(), "auth Service is disabled") {
+			t.Fatalf("expected error on the auth Service disabled, got %v", err)
+		}
+		cm := &k8score.ConfigMap{}
+		if err := r.Client.Get(ctx, client.ObjectKeyFromObject(meshConfigMap()), cm); err != nil || cm.Data["mesh"] != meshConfigMap().Data["mesh"] {
+			t.Errorf("expected the mesh config to be left untouched, got %v, err: %v", cm.Data, err)
+		}
+	})
+
+	t.Run("fails when the mesh config does not exist", func(t *testing.T) {
+		instance := authorinoInstance.DeepCopy()
+		instance.Spec.Integration.Istio = &api.IstioIntegration{}
+
+		r, ctx := setupTestEnvironment(t, []client.Object{instance})
+
+		if err := r.ReconcileAuthorinoIstioIntegration(ctx, instance); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}
+
+func TestGatewayAPIAttachedCondition(t *testing.T) {
+	route := func(parents ...interface{}) *unstructured.Unstructured {
+		obj := authorinoResources.NewAuthRoute(authorinoResources.GRPCRouteGVK, "test-authorino", namespace, DefaultAuthGRPCServicePort, nil, nil, nil)
+		if parents != nil {
+			_ = unstructured.SetNestedSlice(obj.Object, parents, "status", "parents")
+		}
+		return obj
+	}
+	parent := func(name, status, reason string) interface{} {
+		return map[string]interface{}{
+			"parentRef": map[string]interface{}{"name": name, "namespace": "gateway-system"},
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Accepted", "status": status, "reason": reason},
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		obj        *unstructured.Unstructured
+		wantStatus k8score.ConditionStatus
+		wantReason string
+	}{
+		{
+			name:       "no status yet",
+			obj:        route(),
+			wantStatus: k8score.ConditionUnknown,
+			wantReason: statusGatewayAPIAttachmentPending,
+		},
+		{
+			name:       "accepted by all parents",
+			obj:        route(parent("internal", "True", "Accepted"), parent("external", "True", "Accepted")),
+			wantStatus: k8score.ConditionTrue,
+			wantReason: statusGatewayAPIAccepted,
+		},
+		{
+			name:       "not accepted by a parent",
+			obj:        route(parent("internal", "True", "Accepted"), parent("external", "False", "NotAllowedByListeners")),
+			wantStatus: k8score.ConditionFalse,
+			wantReason: statusGatewayAPINotAccepted,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cond := gatewayAPIAttachedCondition("GRPCRoute", tt.obj)
+			if cond.Type != api.ConditionGatewayAPIAttached || cond.Status != tt.wantStatus || cond.Reason != tt.wantReason {
+				t.Errorf("expected condition %s:%s (%s), got %+v", api.ConditionGatewayAPIAttached, tt.wantStatus, tt.wantReason, cond)
+			}
+		})
+	}
+
+	cond := gatewayAPIAttachedCondition("GRPCRoute", route(parent("external", "False", "NotAllowedByListeners")))
+	if cond.Message != "GRPCRoute not accepted by: gateway-system/external (NotAllowedByListeners)" {
+		t.Errorf("unexpected condition message: %q", cond.Message)
+	}
+}
+
+func TestReconcileDrift(t *testing.T) {
+	// reconciles the Deployment of the instance, hand-edits its replicas, and reconciles it again with drift reporting
+	reconcileHandEditedDeployment := func(t *testing.T, policy string) (*AuthorinoReconciler, context.Context, *api.Authorino, *appsv1.Deployment) {
+		t.Helper()
+
+		instance := authorinoInstance.DeepCopy()
+		instance.Spec.DriftPolicy = policy
+		r, ctx := setupTestEnvironment(t, []client.Object{instance})
+
+		if err := r.ReconcileAuthorinoDeployment(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		deployment := &appsv1.Deployment{}
+		if err := r.Client.Get(ctx, client.ObjectKeyFromObject(instance), deployment); err != nil {
+			t.Fatal(err)
+		}
+		deployment.Spec.Replicas = pointer.Int32(7)
+		if err := r.Client.Update(ctx, deployment, client.FieldOwner("kubectl-edit")); err != nil {
+			t.Fatal(err)
+		}
+
+		ctx = WithDriftReport(ctx, instance)
+		if err := r.ReconcileAuthorinoDeployment(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := r.Client.Get(ctx, client.ObjectKeyFromObject(instance), deployment); err != nil {
+			t.Fatal(err)
+		}
+		return r, ctx, instance, deployment
+	}
+
+	driftCondition := func(instance *api.Authorino) *api.Condition {
+		for i := range instance.Status.Conditions {
+			if instance.Status.Conditions[i].Type == api.ConditionDriftDetected {
+				return &instance.Status.Conditions[i]
+			}
+		}
+		return nil
+	}
+
+	t.Run("overwrites the drifted fields by default", func(t *testing.T) {
+		r, ctx, instance, deployment := reconcileHandEditedDeployment(t, "")
+		recorder := events.NewFakeRecorder(1)
+		r.Recorder = recorder
+
+		if *deployment.Spec.Replicas != 2 {
+			t.Errorf("expected the hand-edited replicas to be overwritten, got %d", *deployment.Spec.Replicas)
+		}
+
+		if err := r.ReconcileAuthorinoDriftStatus(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		cond := driftCondition(instance)
+		if cond == nil || cond.Status != k8score.ConditionTrue {
+			t.Fatalf("expected DriftDetected condition to be true, got %+v", cond)
+		}
+		if expected := "Deployment test-namespace/test-authorino: .spec.replicas (kubectl-edit)"; !strings.Contains(cond.Message, expected) {
+			t.Errorf("expected DriftDetected message to contain %q, got %q", expected, cond.Message)
+		}
+		select {
+		case event := <-recorder.Events:
+			if !strings.HasPrefix(event, "Warning DriftDetected") || !strings.Contains(event, "overwritten") {
+				t.Errorf("unexpected event: %s", event)
+			}
+		default:
+			t.Error("expected a DriftDetected event")
+		}
+
+		// no drift left, the drift overwritten is still reported by the reconciliations right after
+		for i := 0; i < 2; i++ {
+			ctx = WithDriftReport(ctx, instance)
+			if err := r.ReconcileAuthorinoDeployment(ctx, instance); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := r.ReconcileAuthorinoDriftStatus(ctx, instance); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if cond := driftCondition(instance); cond == nil || cond.Status != k8score.ConditionTrue {
+				t.Errorf("expected DriftDetected condition to still be true, got %+v", cond)
+			}
+		}
+		if after := RequeueAfter(instance); after <= 0 || after > driftReportSeconds*time.Second {
+			t.Errorf("expected a requeue to clear the drift reported, got %v", after)
+		}
+		select {
+		case event := <-recorder.Events:
+			t.Errorf("unexpected event: %s", event)
+		default:
+		}
+
+		// until the report period is over
+		driftCondition(instance).LastUpdatedTime = &metav1.Time{Time: time.Now().Add(-(driftReportSeconds + 1) * time.Second)}
+		ctx = WithDriftReport(ctx, instance)
+		if err := r.ReconcileAuthorinoDeployment(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := r.ReconcileAuthorinoDriftStatus(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cond := driftCondition(instance); cond == nil || cond.Status != k8score.ConditionFalse {
+			t.Errorf("expected DriftDetected condition to be false, got %+v", cond)
+		}
+	})
+
+	t.Run("reports the drift overwritten again", func(t *testing.T) {
+		r, ctx, instance, deployment := reconcileHandEditedDeployment(t, "Force")
+		recorder := events.NewFakeRecorder(2)
+		r.Recorder = recorder
+		if err := r.ReconcileAuthorinoDriftStatus(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		detected := driftCondition(instance).LastUpdatedTime
+		if detected == nil {
+			t.Fatal("expected the time the drift was detected to be recorded")
+		}
+		driftCondition(instance).LastUpdatedTime = &metav1.Time{Time: detected.Add(-time.Minute)}
+
+		deployment.Spec.Replicas = pointer.Int32(7)
+		if err := r.Client.Update(ctx, deployment, client.FieldOwner("kubectl-edit")); err != nil {
+			t.Fatal(err)
+		}
+		ctx = WithDriftReport(ctx, instance)
+		if err := r.ReconcileAuthorinoDeployment(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := r.ReconcileAuthorinoDriftStatus(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cond := driftCondition(instance); cond == nil || cond.Status != k8score.ConditionTrue || cond.LastUpdatedTime == nil || !cond.LastUpdatedTime.After(detected.Add(-time.Minute)) {
+			t.Errorf("expected DriftDetected condition to be true and updated, got %+v", cond)
+		}
+		if len(recorder.Events) != 2 {
+			t.Errorf("expected a DriftDetected event for each overwrite, got %d", len(recorder.Events))
+		}
+	})
+
+	t.Run("preserves the drifted fields", func(t *testing.T) {
+		r, ctx, instance, deployment := reconcileHandEditedDeployment(t, "Preserve")
+
+		if *deployment.Spec.Replicas != 7 {
+			t.Errorf("expected the hand-edited replicas to be preserved, got %d", *deployment.Spec.Replicas)
+		}
+
+		if err := r.ReconcileAuthorinoDriftStatus(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cond := driftCondition(instance); cond == nil || cond.Status != k8score.ConditionTrue {
+			t.Errorf("expected DriftDetected condition to be true, got %+v", cond)
+		}
+
+		// the other fields are still applied
+		instance.Spec.Image = "quay.io/kuadrant/authorino:v0.99.0"
+		ctx = WithDriftReport(ctx, instance)
+		if err := r.ReconcileAuthorinoDeployment(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := r.Client.Get(ctx, client.ObjectKeyFromObject(instance), deployment); err != nil {
+			t.Fatal(err)
+		}
+		if *deployment.Spec.Replicas != 7 || deployment.Spec.Template.Spec.Containers[0].Image != instance.Spec.Image {
+			t.Errorf("expected the hand-edited replicas to be preserved and the new image applied, got %d replicas and image %s", *deployment.Spec.Replicas, deployment.Spec.Template.Spec.Containers[0].Image)
+		}
+	})
+
+	t.Run("applies the changes of the operator itself", func(t *testing.T) {
+		for _, manager := range []string{fieldOwner, "manager"} {
+			instance := authorinoInstance.DeepCopy()
+			instance.Spec.DriftPolicy = "Preserve"
+			r, ctx := setupTestEnvironment(t, []client.Object{instance})
+
+			// created by the operator, or by former versions of the operator that did not set the field manager
+			deployment := AuthorinoDeployment(instance)
+			if err := ctrl.SetControllerReference(instance, deployment, r.Scheme); err != nil {
+				t.Fatal(err)
+			}
+			if err := r.Client.Create(ctx, deployment, client.FieldOwner(manager)); err != nil {
+				t.Fatal(err)
+			}
+			if err := r.ReconcileAuthorinoDeployment(ctx, instance); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			instance.Spec.Replicas = pointer.Int32(5)
+			ctx = WithDriftReport(ctx, instance)
+			if err := r.ReconcileAuthorinoDeployment(ctx, instance); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := r.Client.Get(ctx, client.ObjectKeyFromObject(instance), deployment); err != nil {
+				t.Fatal(err)
+			}
+			if *deployment.Spec.Replicas != 5 {
+				t.Errorf("expected the replicas set by the operator (%s) to be applied, got %d", manager, *deployment.Spec.Replicas)
+			}
+			if err := r.ReconcileAuthorinoDriftStatus(ctx, instance); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if cond := driftCondition(instance); cond == nil || cond.Status != k8score.ConditionFalse {
+				t.Errorf("expected DriftDetected condition to be false (%s), got %+v", manager, cond)
+			}
+		}
+	})
+
+	t.Run("reports the changes of other controllers", func(t *testing.T) {
+		instance := authorinoInstance.DeepCopy()
+		r, ctx := setupTestEnvironment(t, []client.Object{instance})
+		if err := r.ReconcileAuthorinoDeployment(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		// the default field manager of the controllers built with kubebuilder
+		deployment := &appsv1.Deployment{}
+		if err := r.Client.Get(ctx, client.ObjectKeyFromObject(instance), deployment); err != nil {
+			t.Fatal(err)
+		}
+		deployment.Spec.Replicas = pointer.Int32(7)
+		if err := r.Client.Update(ctx, deployment, client.FieldOwner("manager")); err != nil {
+			t.Fatal(err)
+		}
+
+		ctx = WithDriftReport(ctx, instance)
+		if err := r.ReconcileAuthorinoDeployment(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := r.ReconcileAuthorinoDriftStatus(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cond := driftCondition(instance); cond == nil || cond.Status != k8score.ConditionTrue || !strings.Contains(cond.Message, ".spec.replicas (manager)") {
+			t.Errorf("expected DriftDetected condition to report the replicas modified by manager, got %+v", cond)
+		}
+	})
+}
+
+func TestValidateTracingEndpoint(t *testing.T) {
+	tests := []struct {
+		name     string
+		tracing  api.Tracing
+		err      string
+		warnings []string
+	}{
+		{name: "otlp grpc", tracing: api.Tracing{Endpoint: "rpc://otel-collector.observability:4317"}},
+		{name: "otlp grpc default port", tracing: api.Tracing{Endpoint: "rpc://otel-collector"}},
+		{name: "otlp http", tracing: api.Tracing{Endpoint: "http://jaeger:14268/api/traces", Insecure: true}},
+		{name: "otlp https", tracing: api.Tracing{Endpoint: "https://otel-collector:4318/v1/traces"}},
+		{name: "ipv6 host", tracing: api.Tracing{Endpoint: "rpc://[fd00::1]:4317"}},
+		{name: "no scheme", tracing: api.Tracing{Endpoint: "otel-collector:4317"}, err: "missing scheme"},
+		{name: "no scheme nor port", tracing: api.Tracing{Endpoint: "otel-collector"}, err: "missing scheme"},
+		{name: "unsupported scheme", tracing: api.Tracing{Endpoint: "grpc://otel-collector:4317"}, err: "unsupported scheme grpc"},
+		{name: "no host", tracing: api.Tracing{Endpoint: "http:///v1/traces"}, err: "missing host"},
+		{name: "malformed", tracing: api.Tracing{Endpoint: "rpc://otel collector:4317"}, err: "invalid tracing endpoint"},
+		{name: "insecure https", tracing: api.Tracing{Endpoint: "https://otel-collector/v1/traces", Insecure: true}, warnings: []string{"insecure connection to https:// endpoint"}},
+		{name: "insecure with tls", tracing: api.Tracing{Endpoint: "rpc://otel-collector:4317", Insecure: true, Tls: &api.TracingTls{}}, warnings: []string{"tls settings ignored with insecure connection"}},
+		{name: "grpc to http port", tracing: api.Tracing{Endpoint: "rpc://otel-collector:4318"}, warnings: []string{"rpc:// (OTLP/gRPC) endpoint with the port of OTLP/HTTP (4318)"}},
+		{name: "http to grpc port", tracing: api.Tracing{Endpoint: "http://otel-collector:4317"}, warnings: []string{"http:// (OTLP/HTTP) endpoint with the port of OTLP/gRPC (4317)"}},
+		{name: "grpc with path", tracing: api.Tracing{Endpoint: "rpc://otel-collector:4317/v1/traces"}, warnings: []string{"path /v1/traces of rpc:// (OTLP/gRPC) endpoint ignored"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings, err := ValidateTracingEndpoint(tt.tracing)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Errorf("expected error containing %q, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(warnings, tt.warnings) {
+				t.Errorf("expected warnings %v, got %v", tt.warnings, warnings)
+			}
+		})
+	}
+}
+
+func TestReconcileAuthorinoTracingStatus(t *testing.T) {
+	tracingCondition := func(instance *api.Authorino) *api.Condition {
+		for i := range instance.Status.Conditions {
+			if instance.Status.Conditions[i].Type == api.ConditionTracingConfigured {
+				return &instance.Status.Conditions[i]
+			}
+		}
+		return nil
+	}
+
+	tests := []struct {
+		name     string
+		endpoint string
+		insecure bool
+		status   k8score.ConditionStatus
+		reason   string
+		event    string
+	}{
+		{name: "disabled", status: k8score.ConditionFalse, reason: statusTracingDisabled},
+		{name: "configured", endpoint: "rpc://otel-collector:4317", status: k8score.ConditionTrue, reason: statusTracingConfigured},
+		{name: "configured with warnings", endpoint: "https://otel-collector/v1/traces", insecure: true, status: k8score.ConditionTrue, reason: statusTracingConfigured, event: "Warning TracingConfigured"},
+		{name: "invalid endpoint", endpoint: "otel-collector:4317", status: k8score.ConditionFalse, reason: statusInvalidTracingEndpoint, event: "Warning InvalidTracingEndpoint"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := authorinoInstance.DeepCopy()
+			instance.Spec.Tracing = api.Tracing{Endpoint: tt.endpoint, Insecure: tt.insecure}
+			r, ctx := setupTestEnvironment(t, []client.Object{instance})
+			recorder := events.NewFakeRecorder(2)
+			r.Recorder = recorder
+
+			if err := r.ReconcileAuthorinoTracingStatus(ctx, instance); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			cond := tracingCondition(instance)
+			if cond == nil || cond.Status != tt.status || cond.Reason != tt.reason {
+				t.Fatalf("expected TracingConfigured condition %s with reason %s, got %+v", tt.status, tt.reason, cond)
+			}
+
+			// reported once
+			if err := r.ReconcileAuthorinoTracingStatus(ctx, instance); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var recorded []string
+			for len(recorder.Events) > 0 {
+				recorded = append(recorded, <-recorder.Events)
+			}
+			switch {
+			case tt.event == "" && len(recorded) > 0:
+				t.Errorf("unexpected events %v", recorded)
+			case tt.event != "" && (len(recorded) != 1 || !strings.HasPrefix(recorded[0], tt.event)):
+				t.Errorf("expected one %s event, got %v", tt.event, recorded)
+			}
+		})
+	}
+}
+
+func TestReconcileCanary(t *testing.T) {
+	canaryKey := client.ObjectKey{Namespace: namespace, Name: "test-authorino-canary"}
+
+	newInstance := func(canary *api.Canary) *api.Authorino {
+		instance := authorinoInstance.DeepCopy()
+		instance.Spec.Canary = canary
+		return instance
+	}
+
+	// marks the canary Deployment with all its replicas available, as the Deployment controller would
+	setCanaryAvailable := func(t *testing.T, r *AuthorinoReconciler, ctx context.Context) {
+		t.Helper()
+		deployment := &appsv1.Deployment{}
+		if err := r.Client.Get(ctx, canaryKey, deployment); err != nil {
+			t.Fatal(err)
+		}
+		deployment.Status = appsv1.DeploymentStatus{
+			ObservedGeneration: deployment.Generation,
+			Replicas:           *deployment.Spec.Replicas,
+			UpdatedReplicas:    *deployment.Spec.Replicas,
+			AvailableReplicas:  *deployment.Spec.Replicas,
+		}
+		if err := r.Client.Status().Update(ctx, deployment); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expectCanaryDeleted := func(t *testing.T, r *AuthorinoReconciler, ctx context.Context) {
+		t.Helper()
+		if err := r.Client.Get(ctx, canaryKey, &appsv1.Deployment{}); !apierrors.IsNotFound(err) {
+			t.Errorf("expected the canary Deployment to be deleted, got %v", err)
+		}
+	}
+
+	t.Run("runs the canary image behind the same services", func(t *testing.T) {
+		instance := newInstance(&api.Canary{Image: "quay.io/kuadrant/authorino:canary", Weight: 60})
+		r, ctx := setupTestEnvironment(t, []client.Object{instance})
+
+		if err := r.ReconcileAuthorinoCanary(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		canary := &appsv1.Deployment{}
+		if err := r.Client.Get(ctx, canaryKey, canary); err != nil {
+			t.Fatal(err)
+		}
+		if image := canary.Spec.Template.Spec.Containers[0].Image; image != "quay.io/kuadrant/authorino:canary" {
+			t.Errorf("expected the canary image, got %s", image)
+		}
+		if replicas := *canary.Spec.Replicas; replicas != 2 {
+			t.Errorf("expected 60%% of 2 replicas rounded up, got %d", replicas)
+		}
+		if canary.Spec.Selector.MatchLabels[CanaryLabel] != "true" || canary.Spec.Template.Labels[CanaryLabel] != "true" {
+			t.Errorf("expected the canary label in the selector and pods, got %v and %v", canary.Spec.Selector.MatchLabels, canary.Spec.Template.Labels)
+		}
+		for _, service := range AuthorinoServices(instance) {
+			for key, value := range service.Spec.Selector {
+				if canary.Spec.Template.Labels[key] != value {
+					t.Errorf("expected the canary pods to be selected by the %s Service, got labels %v", service.Name, canary.Spec.Template.Labels)
+				}
+			}
+		}
+		if !hasArg(canary.Spec.Template.Spec.Containers[0].Args, FlagEnableLeaderElection) {
+			t.Error("expected leader election enabled in the canary Deployment")
+		}
+		selector := AuthorinoDeployment(instance).Spec.Selector.MatchLabels
+		if labels.SelectorFromSet(selector).Matches(labels.Set(canary.Spec.Template.Labels)) {
+			t.Errorf("expected the canary pods not to be selected by the Authorino Deployment, got selector %v", selector)
+		}
+
+		if image := AuthorinoDeployment(instance).Spec.Template.Spec.Containers[0].Image; image != authorinoInstance.Spec.Image {
+			t.Errorf("expected the Authorino Deployment to keep its image, got %s", image)
+		}
+
+		status := instance.Status.Canary
+		if status == nil || status.Phase != api.CanaryPhaseProgressing || status.Image != "quay.io/kuadrant/authorino:canary" {
+			t.Fatalf("expected the canary to be progressing, got %+v", status)
+		}
+		if after := CanaryRequeueAfter(instance); after <= 0 || after > 600*time.Second {
+			t.Errorf("expected a requeue within the canary timeout, got %s", after)
+		}
+	})
+
+	t.Run("promotes the canary after the soak period", func(t *testing.T) {
+		instance := newInstance(&api.Canary{Image: "quay.io/kuadrant/authorino:canary", SoakSeconds: pointer.Int32(60)})
+		r, ctx := setupTestEnvironment(t, []client.Object{instance})
+		recorder := events.NewFakeRecorder(1)
+		r.Recorder = recorder
+
+		if err := r.ReconcileAuthorinoCanary(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		setCanaryAvailable(t, r, ctx)
+
+		if err := r.ReconcileAuthorinoCanary(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if phase := instance.Status.Canary.Phase; phase != api.CanaryPhaseSoaking {
+			t.Fatalf("expected the canary to be soaking, got %s", phase)
+		}
+
+		// the soak period elapses
+		instance.Status.Canary.LastTransitionTime = metav1.NewTime(time.Now().Add(-time.Minute))
+		if err := r.ReconcileAuthorinoCanary(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if phase := instance.Status.Canary.Phase; phase != api.CanaryPhasePromoted {
+			t.Fatalf("expected the canary to be promoted, got %s", phase)
+		}
+		expectCanaryDeleted(t, r, ctx)
+		if image := AuthorinoDeployment(instance).Spec.Template.Spec.Containers[0].Image; image != "quay.io/kuadrant/authorino:canary" {
+			t.Errorf("expected the Authorino Deployment to run the canary image, got %s", image)
+		}
+		if after := CanaryRequeueAfter(instance); after != 0 {
+			t.Errorf("expected no requeue once promoted, got %s", after)
+		}
+		select {
+		case event := <-recorder.Events:
+			if !strings.Contains(event, statusCanaryPromoted) {
+				t.Errorf("unexpected event %q", event)
+			}
+		default:
+			t.Error("expected a CanaryPromoted event")
+		}
+
+		stored := &api.Authorino{}
+		if err := r.Client.Get(ctx, client.ObjectKeyFromObject(instance), stored); err != nil {
+			t.Fatal(err)
+		}
+		if stored.Spec.Image != "quay.io/kuadrant/authorino:canary" {
+			t.Errorf("expected the canary image to be set as the image of the instance, got %s", stored.Spec.Image)
+		}
+
+		// the canary is dropped from the spec
+		instance.Spec.Canary = nil
+		if err := r.ReconcileAuthorinoCanary(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if instance.Status.Canary != nil {
+			t.Errorf("expected the canary status to be cleared, got %+v", instance.Status.Canary)
+		}
+		if image := AuthorinoDeployment(instance).Spec.Template.Spec.Containers[0].Image; image != "quay.io/kuadrant/authorino:canary" {
+			t.Errorf("expected the Authorino Deployment to keep running the canary image, got %s", image)
+		}
+	})
+
+	t.Run("rolls back a canary not available in time", func(t *testing.T) {
+		instance := newInstance(&api.Canary{Image: "quay.io/kuadrant/authorino:canary", TimeoutSeconds: pointer.Int32(60)})
+		r, ctx := setupTestEnvironment(t, []client.Object{instance})
+		recorder := events.NewFakeRecorder(1)
+		r.Recorder = recorder
+
+		if err := r.ReconcileAuthorinoCanary(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		instance.Status.Canary.LastTransitionTime = metav1.NewTime(time.Now().Add(-time.Minute))
+		if err := r.ReconcileAuthorinoCanary(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if status := instance.Status.Canary; status.Phase != api.CanaryPhaseRolledBack || !strings.Contains(status.Message, "not available within 1m0s") {
+			t.Fatalf("expected the canary to be rolled back, got %+v", status)
+		}
+		expectCanaryDeleted(t, r, ctx)
+		if image := AuthorinoDeployment(instance).Spec.Template.Spec.Containers[0].Image; image != authorinoInstance.Spec.Image {
+			t.Errorf("expected the Authorino Deployment to keep its image, got %s", image)
+		}
+		select {
+		case event := <-recorder.Events:
+			if !strings.Contains(event, statusCanaryRolledBack) {
+				t.Errorf("unexpected event %q", event)
+			}
+		default:
+			t.Error("expected a CanaryRolledBack event")
+		}
+
+		// a new canary image starts over
+		instance.Spec.Canary.Image = "quay.io/kuadrant/authorino:canary-2"
+		if err := r.ReconcileAuthorinoCanary(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if status := instance.Status.Canary; status.Phase != api.CanaryPhaseProgressing || status.Image != "quay.io/kuadrant/authorino:canary-2" {
+			t.Errorf("expected the new canary to be progressing, got %+v", status)
+		}
+		if err := r.Client.Get(ctx, canaryKey, &appsv1.Deployment{}); err != nil {
+			t.Errorf("expected the canary Deployment to be created again, got %v", err)
+		}
+	})
+
+	t.Run("rolls back a canary whose rollout failed", func(t *testing.T) {
+		instance := newInstance(&api.Canary{Image: "quay.io/kuadrant/authorino:canary"})
+		r, ctx := setupTestEnvironment(t, []client.Object{instance})
+
+		if err := r.ReconcileAuthorinoCanary(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		deployment := &appsv1.Deployment{}
+		if err := r.Client.Get(ctx, canaryKey, deployment); err != nil {
+			t.Fatal(err)
+		}
+		deployment.Status.Conditions = []appsv1.DeploymentCondition{
+			{Type: appsv1.DeploymentProgressing, Status: k8score.ConditionFalse, Reason: "ProgressDeadlineExceeded", Message: `ReplicaSet "test-authorino-canary-7f9c" has timed out progressing.`},
+		}
+		if err := r.Client.Status().Update(ctx, deployment); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := r.ReconcileAuthorinoCanary(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if status := instance.Status.Canary; status.Phase != api.CanaryPhaseRolledBack || !strings.Contains(status.Message, "has timed out progressing") {
+			t.Errorf("expected the canary to be rolled back, got %+v", status)
+		}
+		expectCanaryDeleted(t, r, ctx)
+	})
+
+	t.Run("rolls back a canary unavailable while soaking", func(t *testing.T) {
+		instance := newInstance(&api.Canary{Image: "quay.io/kuadrant/authorino:canary"})
+		r, ctx := setupTestEnvironment(t, []client.Object{instance})
+
+		if err := r.ReconcileAuthorinoCanary(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		setCanaryAvailable(t, r, ctx)
+		if err := r.ReconcileAuthorinoCanary(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		deployment := &appsv1.Deployment{}
+		if err := r.Client.Get(ctx, canaryKey, deployment); err != nil {
+			t.Fatal(err)
+		}
+		deployment.Status.AvailableReplicas = 0
+		if err := r.Client.Status().Update(ctx, deployment); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := r.ReconcileAuthorinoCanary(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if status := instance.Status.Canary; status.Phase != api.CanaryPhaseRolledBack {
+			t.Errorf("expected the canary to be rolled back, got %+v", status)
+		}
+		expectCanaryDeleted(t, r, ctx)
+	})
+}
+
+func TestRenderAuthorino(t *testing.T) {
+	kinds := func(objs []client.Object) []string {
+		var kinds []string
+		for _, obj := range objs {
+			kinds = append(kinds, obj.GetObjectKind().GroupVersionKind().Kind+"/"+obj.GetName())
+		}
+		return kinds
+	}
+
+	t.Run("namespaced instance", func(t *testing.T) {
+		objs, err := RenderAuthorino(authorinoInstance.DeepCopy(), false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := []string{
+			"Service/test-authorino-authorino-authorization",
+			"Service/test-authorino-authorino-oidc",
+			"Service/test-authorino-controller-metrics",
+			"ServiceAccount/test-authorino-authorino",
+			"RoleBinding/test-authorino-authorino",
+			"ClusterRoleBinding/test-namespace.test-authorino-authorino-k8s-auth",
+			"Role/test-authorino-authorino-leader-election",
+			"RoleBinding/test-authorino-authorino-leader-election",
+			"Deployment/test-authorino",
+		}
+		if actual := kinds(objs); !reflect.DeepEqual(actual, expected) {
+			t.Errorf("expected %v, got %v", expected, actual)
+		}
+	})
+
+	t.Run("cluster-wide instance without OIDC server", func(t *testing.T) {
+		instance := authorinoInstance.DeepCopy()
+		instance.Spec.ClusterWide = true
+		instance.Spec.OIDCServer.Service.Enabled = pointer.Bool(false)
+
+		objs, err := RenderAuthorino(instance, false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		actual := kinds(objs)
+		if !slices.Contains(actual, "ClusterRoleBinding/test-namespace.test-authorino-authorino") {
+			t.Errorf("expected the manager ClusterRoleBinding, got %v", actual)
+		}
+		for _, unexpected := range []string{"RoleBinding/test-authorino-authorino", "Service/test-authorino-authorino-oidc"} {
+			if slices.Contains(actual, unexpected) {
+				t.Errorf("expected %s to be omitted, got %v", unexpected, actual)
+			}
+		}
+	})
+
+	t.Run("OIDC server exposed by default with an Ingress or a Route", func(t *testing.T) {
+		instance := authorinoInstance.DeepCopy()
+		instance.Spec.OIDCServer.Expose = &api.OIDCServerExpose{Host: "oidc.example.com"}
+
+		for routeAPIAvailable, expected := range map[bool]string{
+			false: "Ingress/test-authorino-authorino-oidc",
+			true:  "Route/test-authorino-authorino-oidc",
+		} {
+			objs, err := RenderAuthorino(instance, routeAPIAvailable)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual := kinds(objs); !slices.Contains(actual, expected) || len(actual) != 10 {
+				t.Errorf("expected %s along with the other resources, got %v", expected, actual)
+			}
+		}
+	})
+
+	t.Run("fails to integrate without the auth Service", func(t *testing.T) {
+		instance := authorinoInstance.DeepCopy()
+		instance.Spec.Listener.Service.Enabled = pointer.Bool(false)
+		instance.Spec.Integration.Istio = &api.IstioIntegration{}
+
+		if _, err := RenderAuthorino(instance, false); err == nil || !strings.Contains(err.Error(), "auth Service is disabled") {
+			t.Errorf("expected error on the auth Service disabled, got %v", err)
+		}
+	})
+
+	t.Run("removed instance", func(t *testing.T) {
+		instance := authorinoInstance.DeepCopy()
+		instance.Spec.ManagementState = api.ManagementStateRemoved
+
+		objs, err := RenderAuthorino(instance, false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(objs) != 0 {
+			t.Errorf("expected nothing rendered, got %v", kinds(objs))
+		}
+	})
+}
+
+func TestReconcileService(t *testing.T) {
+	t.Run("update existing service", func(t *testing.T) {
+		existingService := &k8score.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-authorino",
+				Namespace: namespace,
+			},
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Service",
+				APIVersion: k8score.SchemeGroupVersion.String(),
+			},
+			Spec: k8score.ServiceSpec{
+				Selector: map[string]string{"old-label": "old-value"},
+			},
+		}
+
+		r, ctx := setupTestEnvironment(t, []client.Object{authorinoInstance, existingService})
+
+		newLabels := map[string]string{"new-label": "new-value"}
+		desiredService := existingService.DeepCopy()
+		desiredService.Spec.Selector = newLabels
+		desiredService.Labels = newLabels
+		err := r.reconcileService(ctx, desiredService, authorinoInstance)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		updated := &k8score.Service{}
+		err = r.Client.Get(ctx, client.ObjectKeyFromObject(desiredService), updated)
+		if err != nil {
+			t.Fatalf("expected service to exist: %v", err)
+		}
+
+		if updated.Spec.Selector["new-label"] != "new-value" {
+			t.Errorf("expected selector 'new-label' to be 'new-value', got %s", updated.Spec.Selector["new-label"])
+		}
+
+		if updated.Labels["new-label"] != "new-value" {
+			t.Errorf("expected label 'new-label' to be 'new-value', got %s", updated.Labels["new-label"])
+		}
+	})
+}
+
+func TestReconcileDeployment(t *testing.T) {
+	t.Run("create new deployment", func(t *testing.T) {
+		r, ctx := setupTestEnvironment(t, []client.Object{authorinoInstance})
+		logger := log.FromContext(ctx)
+
+		deployment := AuthorinoDeployment(authorinoInstance)
+		err := r.reconcileDeployment(ctx, logger, deployment, authorinoInstance)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		created := &appsv1.Deployment{}
+		err = r.Client.Get(ctx, client.ObjectKeyFromObject(deployment), created)
+		if err != nil {
+			t.Fatalf("expected deployment to exist: %v", err)
+		}
+	})
+
+	t.Run("update existing deployment", func(t *testing.T) {
+		existingDeployment := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-authorino",
+				Namespace: namespace,
+			},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: pointer.Int32(1),
+			},
+		}
+
+		r, ctx := setupTestEnvironment(t, []client.Object{authorinoInstance, existingDeployment})
+		logger := log.FromContext(ctx)
+
+		authorinoInstance.Spec.Replicas = pointer.Int32(3)
+		authorinoInstance.Labels = map[string]string{"test": "test1"}
+		desiredDeployment := AuthorinoDeployment(authorinoInstance)
+		err := r.reconcileDeployment(ctx, logger, desiredDeployment, authorinoInstance)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		updated := &appsv1.Deployment{}
+		err = r.Client.Get(ctx, client.ObjectKeyFromObject(desiredDeployment), updated)
+		if err != nil {
+			t.Fatalf("expected deployment to exist: %v", err)
+		}
+
+		if *updated.Spec.Replicas != 3 {
+			t.Errorf("expected 3 replicas after update, got %d", *updated.Spec.Replicas)
+		}
+
+		if updated.ObjectMeta.Labels["test"] != "test1" {
+			t.Errorf("expected label 'test' to be 'test1', got %s", updated.Labels["test"])
+		}
+	})
+
+	t.Run("update existing deployment with no replica mutators", func(t *testing.T) {
+		existingDeployment := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-authorino",
+				Namespace: namespace,
+			},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: pointer.Int32(1),
+			},
+		}
+
+		r, ctx := setupTestEnvironment(t, []client.Object{authorinoInstance, existingDeployment})
+		logger := log.FromContext(ctx)
+
+		authorinoInstance.Spec.Replicas = pointer.Int32(3)
+		authorinoInstance.Labels = map[string]string{"test": "test1"}
+		desiredDeployment := AuthorinoDeployment(authorinoInstance)
+		err := r.reconcileDeployment(ctx, logger, desiredDeployment, authorinoInstance)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		updated := &appsv1.Deployment{}
+		err = r.Client.Get(ctx, client.ObjectKeyFromObject(desiredDeployment), updated)
+		if err != nil {
+			t.Fatalf("expected deployment to exist: %v", err)
+		}
+
+		// With Server-Side Apply, all fields in the desired state are applied
+		// Even if ReplicasMutator is not included, replicas will be updated because
+		// they are part of the desired deployment state
+		if *updated.Spec.Replicas != *authorinoInstance.Spec.Replicas {
+			t.Errorf("expected replicas to be updated to %d, got %d", *authorinoInstance.Spec.Replicas, *updated.Spec.Replicas)
+		}
+
+	})
+
+	t.Run("deployment not ready", func(t *testing.T) {
+		existingDeployment := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-authorino",
+				Namespace: namespace,
+			},
+			Status: appsv1.DeploymentStatus{},
+		}
+
+		r, ctx := setupTestEnvironment(t, []client.Object{authorinoInstance, existingDeployment})
+		logger := log.FromContext(ctx)
+
+		desiredDeployment := AuthorinoDeployment(authorinoInstance)
+		err := r.reconcileDeployment(ctx, logger, desiredDeployment, authorinoInstance)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		updated := &api.Authorino{}
+		err = r.Client.Get(ctx, client.ObjectKeyFromObject(authorinoInstance), updated)
+		if err != nil {
+			t.Fatalf("expected deployment to exist: %v", err)
+		}
+
+		if updated.Status.Conditions[0].Type != api.ConditionReady || updated.Status.Conditions[0].Status != k8score.ConditionFalse {
+			t.Errorf("expected condition type to be %s:%s, got %s", api.ConditionReady, k8score.ConditionFalse, updated.Status.Conditions[0])
+		}
+	})
+
+	t.Run("rollout settings", func(t *testing.T) {
+		instance := authorinoInstance.DeepCopy()
+		maxSurge, maxUnavailable := intstr.FromString("25%"), intstr.FromInt32(0)
+		instance.Spec.Rollout = &api.Rollout{
+			RollingUpdate:           &appsv1.RollingUpdateDeployment{MaxSurge: &maxSurge, MaxUnavailable: &maxUnavailable},
+			MinReadySeconds:         pointer.Int32(10),
+			ProgressDeadlineSeconds: pointer.Int32(120),
+		}
+
+		deployment := AuthorinoDeployment(instance)
+
+		if deployment.Spec.Strategy.Type != appsv1.RollingUpdateDeploymentStrategyType {
+			t.Errorf("expected RollingUpdate strategy, got %s", deployment.Spec.Strategy.Type)
+		}
+		if rollingUpdate := deployment.Spec.Strategy.RollingUpdate; rollingUpdate == nil || rollingUpdate.MaxSurge.String() != "25%" || rollingUpdate.MaxUnavailable.IntValue() != 0 {
+			t.Errorf("unexpected rolling update %+v", rollingUpdate)
+		}
+		if deployment.Spec.MinReadySeconds != 10 {
+			t.Errorf("expected minReadySeconds 10, got %d", deployment.Spec.MinReadySeconds)
+		}
+		if p := deployment.Spec.ProgressDeadlineSeconds; p == nil || *p != 120 {
+			t.Errorf("expected progressDeadlineSeconds 120, got %v", p)
+		}
+
+		if deployment := AuthorinoDeployment(authorinoInstance); deployment.Spec.Strategy.Type != "" || deployment.Spec.ProgressDeadlineSeconds != nil {
+			t.Errorf("expected the Kubernetes defaults without rollout settings, got %+v", deployment.Spec.Strategy)
+		}
+	})
+
+	t.Run("high availability", func(t *testing.T) {
+		instance := authorinoInstance.DeepCopy()
+		instance.Spec.HighAvailability = true
+
+		podSpec := AuthorinoDeployment(instance).Spec.Template.Spec
+
+		var topologyKeys []string
+		for _, constraint := range podSpec.TopologySpreadConstraints {
+			topologyKeys = append(topologyKeys, constraint.TopologyKey)
+			if constraint.LabelSelector.MatchLabels["authorino-resource"] != "test-authorino" {
+				t.Errorf("expected the pods of the instance to be spread, got selector %v", constraint.LabelSelector)
+			}
+		}
+		if expected := []string{"topology.kubernetes.io/zone", "kubernetes.io/hostname"}; !reflect.DeepEqual(topologyKeys, expected) {
+			t.Errorf("expected topology spread constraints %v, got %v", expected, topologyKeys)
+		}
+		if podSpec.Affinity == nil || podSpec.Affinity.PodAntiAffinity == nil || len(podSpec.Affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution) != 1 {
+			t.Fatalf("expected a preferred pod anti-affinity, got %+v", podSpec.Affinity)
+		}
+		if term := podSpec.Affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution[0].PodAffinityTerm; term.TopologyKey != "kubernetes.io/hostname" || term.LabelSelector.MatchLabels["authorino-resource"] != "test-authorino" {
+			t.Errorf("unexpected pod anti-affinity term %+v", term)
+		}
+
+		if podSpec := AuthorinoDeployment(authorinoInstance).Spec.Template.Spec; podSpec.TopologySpreadConstraints != nil || podSpec.Affinity != nil {
+			t.Error("expected no scheduling constraints by default")
+		}
+	})
+
+	t.Run("rollout failed", func(t *testing.T) {
+		existingDeployment := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-authorino",
+				Namespace: namespace,
+			},
+			Status: appsv1.DeploymentStatus{
+				Conditions: []appsv1.DeploymentCondition{
+					{Type: appsv1.DeploymentAvailable, Status: k8score.ConditionTrue},
+					{Type: appsv1.DeploymentProgressing, Status: k8score.ConditionFalse, Reason: "ProgressDeadlineExceeded", Message: `ReplicaSet "test-authorino-5d4b" has timed out progressing.`},
+				},
+			},
+		}
+		instance := authorinoInstance.DeepCopy()
+
+		r, ctx := setupTestEnvironment(t, []client.Object{instance, existingDeployment})
+		logger := log.FromContext(ctx)
+
+		if err := r.reconcileDeployment(ctx, logger, AuthorinoDeployment(instance), instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		cond := instance.Status.Conditions[0]
+		if cond.Type != api.ConditionReady || cond.Status != k8score.ConditionFalse || cond.Reason != statusRolloutFailed {
+			t.Errorf("expected condition %s:%s with reason %s, got %+v", api.ConditionReady, k8score.ConditionFalse, statusRolloutFailed, cond)
+		}
+		if !strings.Contains(cond.Message, "has timed out progressing") {
+			t.Errorf("expected the message of the Deployment controller, got %s", cond.Message)
+		}
+	})
+
+	t.Run("replaces the deployment with a legacy selector", func(t *testing.T) {
+		instance := authorinoInstance.DeepCopy()
+		legacyLabels := map[string]string{"control-plane": "controller-manager", "authorino-resource": "test-authorino"}
+		legacyDeployment := AuthorinoDeployment(instance)
+		legacyDeployment.Spec.Selector.MatchLabels = legacyLabels
+		legacyDeployment.Spec.Template.Labels = legacyLabels
+		legacyReplicaSet := &appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-authorino-5d4b", Namespace: namespace, Labels: legacyLabels},
+			Spec:       appsv1.ReplicaSetSpec{Selector: &metav1.LabelSelector{MatchLabels: legacyLabels}},
+		}
+		r, ctx := setupTestEnvironment(t, []client.Object{instance, legacyDeployment, legacyReplicaSet})
+		key := client.ObjectKeyFromObject(instance)
+
+		// the legacy Deployment is deleted, orphaning its ReplicaSets
+		if err := r.ReconcileAuthorinoDeployment(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := r.Client.Get(ctx, key, &appsv1.Deployment{}); !apierrors.IsNotFound(err) {
+			t.Fatalf("expected the legacy Deployment to be deleted, got %v", err)
+		}
+		if err := r.Client.Get(ctx, client.ObjectKeyFromObject(legacyReplicaSet), &appsv1.ReplicaSet{}); err != nil {
+			t.Fatalf("expected the legacy ReplicaSet to be kept until the new Deployment rolls out, got %v", err)
+		}
+
+		// then created again with the new selector
+		if err := r.ReconcileAuthorinoDeployment(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		deployment := &appsv1.Deployment{}
+		if err := r.Client.Get(ctx, key, deployment); err != nil {
+			t.Fatal(err)
+		}
+		if deployment.Spec.Selector.MatchLabels[CanaryLabel] != "false" {
+			t.Errorf("expected the canary label in the selector, got %v", deployment.Spec.Selector.MatchLabels)
+		}
+
+		// once rolled out, the orphaned ReplicaSets are deleted
+		deployment.Status = appsv1.DeploymentStatus{ObservedGeneration: deployment.Generation, UpdatedReplicas: *deployment.Spec.Replicas, AvailableReplicas: *deployment.Spec.Replicas}
+		if err := r.Client.Status().Update(ctx, deployment); err != nil {
+			t.Fatal(err)
+		}
+		if err := r.ReconcileAuthorinoDeployment(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := r.Client.Get(ctx, client.ObjectKeyFromObject(legacyReplicaSet), &appsv1.ReplicaSet{}); !apierrors.IsNotFound(err) {
+			t.Errorf("expected the legacy ReplicaSet to be deleted, got %v", err)
+		}
+	})
+}
+
+func TestReconcileAuthorinoPodDisruptionBudget(t *testing.T) {
+	instance := authorinoInstance.DeepCopy()
+	instance.Spec.HighAvailability = true
+	r, ctx := setupTestEnvironment(t, []client.Object{instance})
+	pdbKey := client.ObjectKey{Namespace: namespace, Name: "test-authorino"}
+
+	if err := r.ReconcileAuthorinoPodDisruptionBudget(ctx, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pdb := &policyv1.PodDisruptionBudget{}
+	if err := r.Client.Get(ctx, pdbKey, pdb); err != nil {
+		t.Fatalf("expected the PodDisruptionBudget to exist: %v", err)
+	}
+	if pdb.Spec.MaxUnavailable == nil || pdb.Spec.MaxUnavailable.IntValue() != 1 {
+		t.Errorf("expected maxUnavailable 1, got %v", pdb.Spec.MaxUnavailable)
+	}
+	withCanary := instance.DeepCopy()
+	withCanary.Spec.Canary = &api.Canary{Image: "quay.io/kuadrant/authorino:canary"}
+	for _, deployment := range []*appsv1.Deployment{AuthorinoDeployment(instance), AuthorinoCanaryDeployment(withCanary)} {
+		for key, value := range pdb.Spec.Selector.MatchLabels {
+			if deployment.Spec.Template.Labels[key] != value {
+				t.Errorf("expected the pods of the %s Deployment to be selected, got %v", deployment.Name, pdb.Spec.Selector.MatchLabels)
+			}
+		}
+	}
+
+	instance.Spec.HighAvailability = false
+	if err := r.ReconcileAuthorinoPodDisruptionBudget(ctx, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := r.Client.Get(ctx, pdbKey, pdb); !apierrors.IsNotFound(err) {
+		t.Errorf("expected the PodDisruptionBudget to be deleted, got %v", err)
+	}
+}
+
+func TestReconcileRollback(t *testing.T) {
+	getDeployment := func(t *testing.T, r *AuthorinoReconciler, ctx context.Context) *appsv1.Deployment {
+		t.Helper()
+		deployment := &appsv1.Deployment{}
+		if err := r.Client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: "test-authorino"}, deployment); err != nil {
+			t.Fatal(err)
+		}
+		return deployment
+	}
+
+	// marks all the replicas of the Deployment updated, and available or not, as the Deployment controller would, along
+	// with the ReplicaSet of its pod template
+	setDeploymentStatus := func(t *testing.T, r *AuthorinoReconciler, ctx context.Context, available bool) {
+		t.Helper()
+		deployment := getDeployment(t, r, ctx)
+		replicaSet := &appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      deployment.Name + "-" + podTemplateRevision(&deployment.Spec.Template),
+				Namespace: deployment.Namespace,
+				Labels:    deployment.Spec.Selector.MatchLabels,
+			},
+			Spec: appsv1.ReplicaSetSpec{Selector: deployment.Spec.Selector, Template: *deployment.Spec.Template.DeepCopy()},
+		}
+		replicaSet.Spec.Template.Labels[appsv1.DefaultDeploymentUniqueLabelKey] = podTemplateRevision(&deployment.Spec.Template)
+		if err := ctrl.SetControllerReference(deployment, replicaSet, r.Scheme); err != nil {
+			t.Fatal(err)
+		}
+		if err := r.Client.Create(ctx, replicaSet); err != nil && !apierrors.IsAlreadyExists(err) {
+			t.Fatal(err)
+		}
+		deployment.Status = appsv1.DeploymentStatus{
+			ObservedGeneration: deployment.Generation,
+			Replicas:           *deployment.Spec.Replicas,
+			UpdatedReplicas:    *deployment.Spec.Replicas,
+		}
+		if available {
+			deployment.Status.AvailableReplicas = *deployment.Spec.Replicas
+		}
+		if err := r.Client.Status().Update(ctx, deployment); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		instance := authorinoInstance.DeepCopy()
+		r, ctx := setupTestEnvironment(t, []client.Object{instance})
+
+		if err := r.ReconcileAuthorinoDeployment(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, ok := getDeployment(t, r, ctx).Annotations[DeploymentRevisionAnnotation]; ok {
+			t.Error("expected no revision annotation without automatic rollback")
+		}
+		if instance.Status.Rollout != nil {
+			t.Errorf("expected no rollout status, got %+v", instance.Status.Rollout)
+		}
+	})
+
+	t.Run("rolls back to the last known-good revision", func(t *testing.T) {
+		instance := authorinoInstance.DeepCopy()
+		instance.Spec.Rollout = &api.Rollout{RollbackAfterSeconds: pointer.Int32(60)}
+		r, ctx := setupTestEnvironment(t, []client.Object{instance})
+		recorder := events.NewFakeRecorder(2)
+		r.Recorder = recorder
+
+		// first revision becomes available
+		if err := r.ReconcileAuthorinoDeployment(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		goodRevision := instance.Status.Rollout.Revision
+		if revision := getDeployment(t, r, ctx).Annotations[DeploymentRevisionAnnotation]; revision != goodRevision {
+			t.Fatalf("expected the Deployment at revision %s, got %s", goodRevision, revision)
+		}
+		setDeploymentStatus(t, r, ctx, true)
+		if err := r.ReconcileAuthorinoDeployment(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if status := instance.Status.Rollout; status.LastKnownGoodRevision != goodRevision || status.LastKnownGoodReplicaSet == "" {
+			t.Fatalf("expected revision %s to be known-good, got %+v", goodRevision, status)
+		}
+
+		// a broken revision is applied and never becomes available
+		instance.Spec.Image = "quay.io/kuadrant/authorino:broken"
+		if err := r.ReconcileAuthorinoDeployment(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		badRevision := instance.Status.Rollout.Revision
+		if badRevision == goodRevision {
+			t.Fatal("expected a new revision")
+		}
+		if image := getDeployment(t, r, ctx).Spec.Template.Spec.Containers[0].Image; image != "quay.io/kuadrant/authorino:broken" {
+			t.Fatalf("expected the new revision to be applied, got image %s", image)
+		}
+		if after := RequeueAfter(instance); after <= 0 || after > time.Minute {
+			t.Errorf("expected a requeue within the rollback timeout, got %s", after)
+		}
+		setDeploymentStatus(t, r, ctx, false)
+
+		// the timeout elapses
+		instance.Status.Rollout.RevisionTime = metav1.NewTime(time.Now().Add(-time.Minute))
+		if err := r.ReconcileAuthorinoDeployment(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		status := instance.Status.Rollout
+		if status.FailedRevision != badRevision || !strings.Contains(status.FailureReason, "not available within 1m0s") {
+			t.Fatalf("expected revision %s to be recorded as failed, got %+v", badRevision, status)
+		}
+		deployment := getDeployment(t, r, ctx)
+		if image := deployment.Spec.Template.Spec.Containers[0].Image; image != authorinoInstance.Spec.Image {
+			t.Errorf("expected the known-good pod template to be re-applied, got image %s", image)
+		}
+		if revision := deployment.Annotations[DeploymentRevisionAnnotation]; revision != goodRevision {
+			t.Errorf("expected the Deployment at revision %s, got %s", goodRevision, revision)
+		}
+		select {
+		case event := <-recorder.Events:
+			if !strings.Contains(event, statusRolledBack) || !strings.Contains(event, badRevision) {
+				t.Errorf("unexpected event %q", event)
+			}
+		default:
+			t.Error("expected a RolledBack event")
+		}
+
+		// stays rolled back while the spec does not change
+		setDeploymentStatus(t, r, ctx, true)
+		if err := r.ReconcileAuthorinoDeployment(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if image := getDeployment(t, r, ctx).Spec.Template.Spec.Containers[0].Image; image != authorinoInstance.Spec.Image {
+			t.Errorf("expected the Deployment to stay rolled back, got image %s", image)
+		}
+		ready := instance.Status.Conditions[0]
+		if ready.Type != api.ConditionReady || ready.Status != k8score.ConditionFalse || ready.Reason != statusRolledBack {
+			t.Errorf("expected condition %s:%s with reason %s, got %+v", api.ConditionReady, k8score.ConditionFalse, statusRolledBack, ready)
+		}
+		if after := RequeueAfter(instance); after != 0 {
+			t.Errorf("expected no requeue once rolled back, got %s", after)
+		}
+		select {
+		case event := <-recorder.Events:
+			t.Errorf("unexpected event %q", event)
+		default:
+		}
+
+		// a fixed spec is a new revision
+		instance.Spec.Image = "quay.io/kuadrant/authorino:fixed"
+		if err := r.ReconcileAuthorinoDeployment(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if image := getDeployment(t, r, ctx).Spec.Template.Spec.Containers[0].Image; image != "quay.io/kuadrant/authorino:fixed" {
+			t.Errorf("expected the new revision to be applied, got image %s", image)
+		}
+	})
+
+	t.Run("rolls back a failed rollout", func(t *testing.T) {
+		instance := authorinoInstance.DeepCopy()
+		instance.Spec.Rollout = &api.Rollout{RollbackAfterSeconds: pointer.Int32(600)}
+		r, ctx := setupTestEnvironment(t, []client.Object{instance})
+
+		if err := r.ReconcileAuthorinoDeployment(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		setDeploymentStatus(t, r, ctx, true)
+		if err := r.ReconcileAuthorinoDeployment(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		instance.Spec.LogLevel = "debug"
+		if err := r.ReconcileAuthorinoDeployment(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		deployment := getDeployment(t, r, ctx)
+		deployment.Status = appsv1.DeploymentStatus{
+			ObservedGeneration: deployment.Generation,
+			Conditions: []appsv1.DeploymentCondition{
+				{Type: appsv1.DeploymentProgressing, Status: k8score.ConditionFalse, Reason: "ProgressDeadlineExceeded", Message: `ReplicaSet "test-authorino-5d4b" has timed out progressing.`},
+			},
+		}
+		if err := r.Client.Status().Update(ctx, deployment); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := r.ReconcileAuthorinoDeployment(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if status := instance.Status.Rollout; status.FailedRevision != status.Revision || !strings.Contains(status.FailureReason, "has timed out progressing") {
+			t.Errorf("expected the revision to be rolled back, got %+v", status)
+		}
+		deployment = getDeployment(t, r, ctx)
+		if hasArg(deployment.Spec.Template.Spec.Containers[0].Args, FlagLogLevel) {
+			t.Error("expected the known-good pod template to be re-applied")
+		}
+		if _, ok := deployment.Spec.Template.Labels[appsv1.DefaultDeploymentUniqueLabelKey]; ok {
+			t.Errorf("expected the pod template without the label of the ReplicaSet, got labels %v", deployment.Spec.Template.Labels)
+		}
+	})
+
+	t.Run("does not roll back once the known-good ReplicaSet is gone", func(t *testing.T) {
+		instance := authorinoInstance.DeepCopy()
+		instance.Spec.Rollout = &api.Rollout{RollbackAfterSeconds: pointer.Int32(60)}
+		r, ctx := setupTestEnvironment(t, []client.Object{instance})
+
+		if err := r.ReconcileAuthorinoDeployment(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		setDeploymentStatus(t, r, ctx, true)
+		if err := r.ReconcileAuthorinoDeployment(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		replicaSet := &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: instance.Status.Rollout.LastKnownGoodReplicaSet}}
+		if err := r.Client.Delete(ctx, replicaSet); err != nil {
+			t.Fatal(err)
+		}
+
+		instance.Spec.Image = "quay.io/kuadrant/authorino:broken"
+		if err := r.ReconcileAuthorinoDeployment(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		setDeploymentStatus(t, r, ctx, false)
+		instance.Status.Rollout.RevisionTime = metav1.NewTime(time.Now().Add(-time.Minute))
+		if err := r.ReconcileAuthorinoDeployment(ctx, instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if image := getDeployment(t, r, ctx).Spec.Template.Spec.Containers[0].Image; image != "quay.io/kuadrant/authorino:broken" {
+			t.Errorf("expected the Deployment not to be rolled back, got image %s", image)
+		}
+		if status := instance.Status.Rollout; status.LastKnownGoodReplicaSet != "" {
+			t.Errorf("expected no known-good ReplicaSet, got %+v", status)
+		}
+		if after := RequeueAfter(instance); after != 0 {
+			t.Errorf("expected no requeue without a revision to roll back to, got %s", after)
+		}
+	})
+}
+
+func clusterRoleBindingSubject(namespace, crName string) k8srbac.Subject {
+	sa := authorinoResources.GetAuthorinoServiceAccount(namespace, crName, nil)
+	return authorinoResources.GetSubjectForRoleBinding(sa)
+}
+
+func TestCleanupLegacyClusterRoleBindings(t *testing.T) {
+	const suffix = AuthorinoK8sAuthClusterRoleBindingName
+
+	t.Run("deletes legacy binding owned by this instance", func(t *testing.T) {
+		instance := &api.Authorino{
+			ObjectMeta: metav1.ObjectMeta{Name: "gateway", Namespace: "team-a"},
+		}
+		legacyName := instance.Name + "-" + suffix
+		binding := &k8srbac.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: legacyName},
+			RoleRef:    k8srbac.RoleRef{APIGroup: k8srbac.GroupName, Kind: "ClusterRole", Name: AuthorinoK8sAuthClusterRoleName},
+			Subjects:   []k8srbac.Subject{clusterRoleBindingSubject("team-a", "gateway")},
+		}
+		r, ctx := setupTestEnvironment(t, []client.Object{binding})
+
+		r.cleanupLegacyClusterRoleBindings(ctx, instance)
+
+		err := r.Client.Get(ctx, client.ObjectKey{Name: legacyName}, &k8srbac.ClusterRoleBinding{})
+		if !apierrors.IsNotFound(err) {
+			t.Fatalf("expected legacy binding %q to be deleted, got err=%v", legacyName, err)
+		}
+	})
+
+	t.Run("preserves colliding legacy binding owned by another instance", func(t *testing.T) {
+		// A legacy CR named "team-a.gateway" (in namespace "team") produced a
+		// binding named "team-a.gateway-<suffix>", which is byte-identical to the
+		// new canonical name for namespace "team-a" / CR "gateway". Reconciling
+		// the "team-a.gateway" instance must not delete (or alter) a binding that
+		// belongs to the "team-a"/"gateway" instance.
+		instance := &api.Authorino{
+			ObjectMeta: metav1.ObjectMeta{Name: "team-a.gateway", Namespace: "team"},
+		}
+		legacyName := instance.Name + "-" + suffix // "team-a.gateway-<suffix>"
+
+		foreignSubject := clusterRoleBindingSubject("team-a", "gateway")
+		binding := &k8srbac.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: legacyName},
+			RoleRef:    k8srbac.RoleRef{APIGroup: k8srbac.GroupName, Kind: "ClusterRole", Name: AuthorinoK8sAuthClusterRoleName},
+			Subjects:   []k8srbac.Subject{foreignSubject},
+		}
+		r, ctx := setupTestEnvironment(t, []client.Object{binding})
+
+		r.cleanupLegacyClusterRoleBindings(ctx, instance)
+
+		got := &k8srbac.ClusterRoleBinding{}
+		if err := r.Client.Get(ctx, client.ObjectKey{Name: legacyName}, got); err != nil {
+			t.Fatalf("expected colliding binding %q to be preserved, got err=%v", legacyName, err)
+		}
+		if len(got.Subjects) != 1 || got.Subjects[0] != foreignSubject {
+			t.Errorf("expected legacy subject to be preserved, got %+v", got.Subjects)
+		}
+	})
+}
+
+func TestCleanupLegacyLeaderElectionRole(t *testing.T) {
+	legacyRole := func(owners ...metav1.OwnerReference) *k8srbac.Role {
+		return &k8srbac.Role{
+			ObjectMeta: metav1.ObjectMeta{Name: AuthorinoLeaderElectionRoleName, Namespace: namespace, OwnerReferences: owners},
+			Rules:      authorinoResources.GetLeaderElectionRules(),
+		}
+	}
+	legacyBinding := func(authorino *api.Authorino) *k8srbac.RoleBinding {
+		binding := leaderElectionRoleBinding(authorino)
+		binding.RoleRef.Name = AuthorinoLeaderElectionRoleName
+		return binding
+	}
+
+	t.Run("deletes the shared Role once no instance binds it", func(t *testing.T) {
+		instance := authorinoInstance.DeepCopy()
+		other := authorinoInstance.DeepCopy()
+		other.Name = "other-authorino"
+		role := legacyRole(*metav1.NewControllerRef(instance, api.GroupVersion.WithKind("Authorino")))
+		r, ctx := setupTestEnvironment(t, []client.Object{instance, other, role, legacyBinding(instance), legacyBinding(other)})
+
+		migrate := func(a *api.Authorino) {
+			t.Helper()
+			if err := r.reconcileLeaderElectionRole(ctx, a); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := r.reconcileLeaderElectionRoleBinding(ctx, a); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			r.cleanupLegacyLeaderElectionRole(ctx, a)
+		}
+
+		migrate(instance)
+		if err := r.Client.Get(ctx, client.ObjectKeyFromObject(role), &k8srbac.Role{}); err != nil {
+			t.Errorf("expected the shared Role to be kept while %s binds it, got err: %v", other.Name, err)
+		}
+
+		migrate(other)
+		if err := r.Client.Get(ctx, client.ObjectKeyFromObject(role), &k8srbac.Role{}); !apierrors.IsNotFound(err) {
+			t.Errorf("expected the shared Role to be deleted, got err: %v", err)
+		}
+	})
+
+	t.Run("preserves a Role not created by the operator", func(t *testing.T) {
+		instance := authorinoInstance.DeepCopy()
+		role := legacyRole()
+		r, ctx := setupTestEnvironment(t, []client.Object{instance, role})
+
+		r.cleanupLegacyLeaderElectionRole(ctx, instance)
+
+		if err := r.Client.Get(ctx, client.ObjectKeyFromObject(role), &k8srbac.Role{}); err != nil {
+			t.Errorf("expected the Role not controlled by an Authorino instance to be kept, got err: %v", err)
 		}
 	})
 }
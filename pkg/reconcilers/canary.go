@@ -0,0 +1,248 @@
+package reconcilers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/go-logr/logr"
+	k8sapps "k8s.io/api/apps/v1"
+	k8score "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	k8smeta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/kuadrant/authorino-operator/api/v1beta1"
+)
+
+// CanaryLabel tells the pods of the canary Deployment ("true") apart from the ones of the Authorino Deployment
+// ("false"), which are otherwise selected by the same Services
+const CanaryLabel = "operator.authorino.kuadrant.io/canary"
+
+// CanaryDeploymentName returns the name of the canary Deployment of the Authorino instance
+func CanaryDeploymentName(authorinoName string) string {
+	return authorinoName + "-canary"
+}
+
+// AuthorinoCanaryDeployment builds the canary Deployment of the Authorino instance, i.e. the Authorino Deployment with
+// the canary image, a share of the replicas and the canary label added to its selector and pods.
+// The Deployment is tagged to delete unless a canary is in progress.
+func AuthorinoCanaryDeployment(authorino *api.Authorino) *k8sapps.Deployment {
+	if !canaryInProgress(authorino) {
+		deployment := &k8sapps.Deployment{
+			TypeMeta: k8smeta.TypeMeta{
+				APIVersion: k8sapps.SchemeGroupVersion.String(),
+				Kind:       "Deployment",
+			},
+			ObjectMeta: k8smeta.ObjectMeta{
+				Name:      CanaryDeploymentName(authorino.Name),
+				Namespace: authorino.Namespace,
+			},
+		}
+		TagObjectToDelete(deployment)
+		return deployment
+	}
+
+	deployment := authorinoDeployment(authorino, authorino.Spec.Canary.Image)
+	deployment.Name = CanaryDeploymentName(authorino.Name)
+	replicas := canaryReplicas(authorino)
+	deployment.Spec.Replicas = &replicas
+	deployment.Spec.Selector.MatchLabels[CanaryLabel] = "true"
+	deployment.Spec.Template.Labels[CanaryLabel] = "true"
+	return deployment
+}
+
+// ReconcileAuthorinoCanary moves the canary of the Authorino instance through its phases, out of the status of the
+// canary Deployment, and reconciles the canary Deployment accordingly.
+// A canary available for the soak period is promoted, i.e. its image is set as the image of the instance, so the
+// Authorino Deployment runs it from then on, canary or not; a canary whose rollout fails, that is not available in time
+// or that becomes unavailable while soaking is rolled back. Either way, the canary Deployment is removed.
+func (r *AuthorinoReconciler) ReconcileAuthorinoCanary(ctx context.Context, authorinoInstance *api.Authorino) error {
+	logger, err := logr.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	var status *api.CanaryStatus
+	if canary := authorinoInstance.Spec.Canary; canary != nil {
+		status = authorinoInstance.Status.Canary.DeepCopy()
+		if status == nil || status.Image != canary.Image {
+			status = &api.CanaryStatus{
+				Image:              canary.Image,
+				Phase:              api.CanaryPhaseProgressing,
+				LastTransitionTime: k8smeta.Now(),
+			}
+		}
+	}
+
+	if status != nil && (status.Phase == api.CanaryPhaseProgressing || status.Phase == api.CanaryPhaseSoaking) {
+		deployment := &k8sapps.Deployment{}
+		err := r.Client.Get(ctx, client.ObjectKey{Namespace: authorinoInstance.Namespace, Name: CanaryDeploymentName(authorinoInstance.Name)}, deployment)
+		switch {
+		case errors.IsNotFound(err):
+			// not created yet
+		case err != nil:
+			return r.WrapErrorWithStatusUpdate(logger, authorinoInstance, r.SetStatusFailed(statusUnableToReconcileCanary),
+				fmt.Errorf("failed to get %s canary Deployment resource, err: %v", authorinoInstance.Name, err))
+		default:
+			advanceCanary(authorinoInstance.Spec.Canary, status, deployment, time.Now())
+		}
+	}
+
+	if status != nil && status.Phase == api.CanaryPhasePromoted && !reflect.DeepEqual(authorinoInstance.Status.Canary, status) {
+		if err := r.promoteCanaryImage(ctx, authorinoInstance, status.Image); err != nil {
+			return r.WrapErrorWithStatusUpdate(logger, authorinoInstance, r.SetStatusFailed(statusUnableToReconcileCanary),
+				fmt.Errorf("failed to promote %s canary image, err: %v", authorinoInstance.Name, err))
+		}
+	}
+
+	if !reflect.DeepEqual(authorinoInstance.Status.Canary, status) {
+		authorinoInstance.Status.Canary = status
+		if err := r.updateStatusConditions(authorinoInstance); err != nil {
+			return err
+		}
+		r.recordCanaryEvent(authorinoInstance)
+	}
+
+	desired := AuthorinoCanaryDeployment(authorinoInstance)
+	if err := ctrl.SetControllerReference(authorinoInstance, desired, r.Scheme); err != nil {
+		return r.WrapErrorWithStatusUpdate(logger, authorinoInstance, r.SetStatusFailed(statusUnableToReconcileCanary),
+			fmt.Errorf("failed to set owner reference for Authorino canary Deployment: %s, err: %v", authorinoInstance.Name, err))
+	}
+	if _, _, err := r.reconcileResource(ctx, &k8sapps.Deployment{}, desired); err != nil {
+		return r.WrapErrorWithStatusUpdate(logger, authorinoInstance, r.SetStatusFailed(statusUnableToReconcileCanary),
+			fmt.Errorf("failed to reconcile %s canary Deployment resource, err: %v", authorinoInstance.Name, err))
+	}
+
+	return nil
+}
+
+// promoteCanaryImage sets the canary image as the image of the Authorino instance, patching only that field of the spec
+func (r *AuthorinoReconciler) promoteCanaryImage(ctx context.Context, authorinoInstance *api.Authorino, image string) error {
+	patch, err := json.Marshal(map[string]interface{}{"spec": map[string]interface{}{"image": image}})
+	if err != nil {
+		return err
+	}
+	if err := r.Client.Patch(ctx, authorinoInstance.DeepCopy(), client.RawPatch(types.MergePatchType, patch)); err != nil {
+		return err
+	}
+	authorinoInstance.Spec.Image = image
+	return nil
+}
+
+// advanceCanary moves the canary to its next phase, if any, given the status of the canary Deployment
+func advanceCanary(canary *api.Canary, status *api.CanaryStatus, deployment *k8sapps.Deployment, now time.Time) {
+	transition := func(phase, message string) {
+		status.Phase = phase
+		status.Message = message
+		status.LastTransitionTime = k8smeta.NewTime(now)
+	}
+
+	available := DeploymentRolledOut(deployment)
+	if failed, message := DeploymentRolloutFailed(deployment); failed {
+		transition(api.CanaryPhaseRolledBack, fmt.Sprintf("canary rollout failed: %s", message))
+		return
+	}
+
+	if status.Phase == api.CanaryPhaseProgressing {
+		switch {
+		case available:
+			transition(api.CanaryPhaseSoaking, "canary available")
+		case now.Sub(status.LastTransitionTime.Time) >= canaryTimeout(canary):
+			transition(api.CanaryPhaseRolledBack, fmt.Sprintf("canary not available within %s", canaryTimeout(canary)))
+			return
+		default:
+			return
+		}
+	}
+
+	switch {
+	case !available:
+		transition(api.CanaryPhaseRolledBack, "canary became unavailable during the soak period")
+	case now.Sub(status.LastTransitionTime.Time) >= canarySoak(canary):
+		transition(api.CanaryPhasePromoted, fmt.Sprintf("canary available for %s", canarySoak(canary)))
+	}
+}
+
+func (r *AuthorinoReconciler) recordCanaryEvent(authorinoInstance *api.Authorino) {
+	status := authorinoInstance.Status.Canary
+	if r.Recorder == nil || status == nil {
+		return
+	}
+	switch status.Phase {
+	case api.CanaryPhasePromoted:
+		r.Recorder.Eventf(authorinoInstance, nil, k8score.EventTypeNormal, statusCanaryPromoted, "PromoteCanary",
+			"Canary image %s promoted to the Authorino Deployment: %s", status.Image, status.Message)
+	case api.CanaryPhaseRolledBack:
+		r.Recorder.Eventf(authorinoInstance, nil, k8score.EventTypeWarning, statusCanaryRolledBack, "RollBackCanary",
+			"Canary image %s rolled back: %s", status.Image, status.Message)
+	}
+}
+
+// CanaryRequeueAfter returns when the canary of the Authorino instance is due to be promoted or rolled back for not
+// becoming available in time, so the instance is reconciled again even if nothing else changes.
+// Zero means no canary is in progress.
+func CanaryRequeueAfter(authorino *api.Authorino) time.Duration {
+	canary, status := authorino.Spec.Canary, authorino.Status.Canary
+	if canary == nil || status == nil || status.Image != canary.Image {
+		return 0
+	}
+
+	var period time.Duration
+	switch status.Phase {
+	case api.CanaryPhaseProgressing:
+		period = canaryTimeout(canary)
+	case api.CanaryPhaseSoaking:
+		period = canarySoak(canary)
+	default:
+		return 0
+	}
+
+	if after := time.Until(status.LastTransitionTime.Add(period)); after > time.Second {
+		return after
+	}
+	return time.Second
+}
+
+// canaryInProgress tells whether the canary of the Authorino instance is still to be promoted or rolled back
+func canaryInProgress(authorino *api.Authorino) bool {
+	canary, status := authorino.Spec.Canary, authorino.Status.Canary
+	if canary == nil {
+		return false
+	}
+	return status == nil || status.Image != canary.Image || status.Phase == api.CanaryPhaseProgressing || status.Phase == api.CanaryPhaseSoaking
+}
+
+// canaryReplicas returns the number of replicas of the canary Deployment, i.e. the share of the replicas of the
+// Authorino instance set by the weight of the canary, rounded up
+func canaryReplicas(authorino *api.Authorino) int32 {
+	replicas := int32(1)
+	if authorino.Spec.Replicas != nil && *authorino.Spec.Replicas > 0 {
+		replicas = *authorino.Spec.Replicas
+	}
+	weight := authorino.Spec.Canary.Weight
+	if weight <= 0 {
+		weight = defaultCanaryWeight
+	}
+	return (replicas*weight + 99) / 100
+}
+
+func canarySoak(canary *api.Canary) time.Duration {
+	seconds := defaultCanarySoakSeconds
+	if canary.SoakSeconds != nil {
+		seconds = *canary.SoakSeconds
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+func canaryTimeout(canary *api.Canary) time.Duration {
+	seconds := defaultCanaryTimeoutSeconds
+	if canary.TimeoutSeconds != nil {
+		seconds = *canary.TimeoutSeconds
+	}
+	return time.Duration(seconds) * time.Second
+}
@@ -3,18 +3,28 @@ package reconcilers
 const (
 	DeleteTagAnnotation = "authorino.kuadrant.io/delete"
 
+	// field manager of the resources applied by the operator
+	fieldOwner = "authorino-operator"
+
 	RelatedImageAuthorino = "RELATED_IMAGE_AUTHORINO"
 
 	// kubernetes objects
 	AuthorinoContainerName                 string = "authorino"
 	AuthorinoTlsCertVolumeName             string = "tls-cert"
 	AuthorinoOidcTlsCertVolumeName         string = "oidc-cert"
+	AuthorinoTracingCACertVolumeName       string = "tracing-ca-cert"
+	AuthorinoTracingClientCertVolumeName   string = "tracing-client-cert"
 	AuthorinoManagerClusterRoleName        string = "authorino-manager-role"
 	AuthorinoK8sAuthClusterRoleName        string = "authorino-manager-k8s-auth-role"
-	AuthorinoLeaderElectionRoleName        string = "authorino-leader-election-role"
 	AuthorinoManagerClusterRoleBindingName string = "authorino"
 	AuthorinoK8sAuthClusterRoleBindingName string = "authorino-k8s-auth"
 	authorinoLeaderElectionRoleBindingName string = "authorino-leader-election"
+	AuthorinoRBACRulesRoleName             string = "authorino-rules"
+	AuthorinoLeaderElectionRoleNameSuffix  string = "authorino-leader-election"
+
+	// Role shared by the Authorino instances of a namespace, before the leader election Roles were named after the
+	// instances
+	AuthorinoLeaderElectionRoleName string = "authorino-leader-election-role"
 
 	// env vars / command-line flags
 	EnvWatchNamespace          string = "WATCH_NAMESPACE"
@@ -33,6 +43,14 @@ const (
 	EnvOidcTlsCertPath         string = "OIDC_TLS_CERT"
 	EnvOidcTlsCertKeyPath      string = "OIDC_TLS_CERT_KEY"
 	EnvMaxHttpRequestBodySize  string = "MAX_HTTP_REQUEST_BODY_SIZE"
+	EnvTracingServiceHeader    string = "TRACING_SERVICE_HEADER"
+	// settings of the OpenTelemetry exporter and sampler, read from the env by the OpenTelemetry SDK of Authorino
+	EnvOtelExporterTracesHeaders           string = "OTEL_EXPORTER_OTLP_TRACES_HEADERS"
+	EnvOtelExporterTracesCertificate       string = "OTEL_EXPORTER_OTLP_TRACES_CERTIFICATE"
+	EnvOtelExporterTracesClientCertificate string = "OTEL_EXPORTER_OTLP_TRACES_CLIENT_CERTIFICATE"
+	EnvOtelExporterTracesClientKey         string = "OTEL_EXPORTER_OTLP_TRACES_CLIENT_KEY"
+	EnvOtelTracesSampler                   string = "OTEL_TRACES_SAMPLER"
+	EnvOtelTracesSamplerArg                string = "OTEL_TRACES_SAMPLER_ARG"
 
 	FlagWatchNamespace                 string = "watch-namespace"
 	FlagWatchedAuthConfigLabelSelector string = "auth-config-label-selector"
@@ -40,6 +58,10 @@ const (
 	FlagSupersedingHostSubsets         string = "allow-superseding-host-subsets"
 	FlagLogLevel                       string = "log-level"
 	FlagLogMode                        string = "log-mode"
+	FlagLogEncoder                     string = "log-encoder"
+	FlagLogTimeFormat                  string = "log-time-format"
+	FlagLogSamplingInitial             string = "log-sampling-initial"
+	FlagLogSamplingThereafter          string = "log-sampling-thereafter"
 	FlagTimeout                        string = "timeout"
 	FlagExtAuthGRPCPort                string = "ext-auth-grpc-port"
 	FlagExtAuthHTTPPort                string = "ext-auth-http-port"
@@ -56,6 +78,10 @@ const (
 	FlagMetricsAddr                    string = "metrics-addr"
 	FlagHealthProbeAddr                string = "health-probe-addr"
 	FlagEnableLeaderElection           string = "enable-leader-election"
+	FlagLeaderElectionLeaseDuration    string = "leader-election-lease-duration"
+	FlagLeaderElectionRenewDeadline    string = "leader-election-renew-deadline"
+	FlagLeaderElectionRetryPeriod      string = "leader-election-retry-period"
+	FlagLeaderElectionID               string = "leader-election-id"
 	FlagMaxHttpRequestBodySize         string = "max-http-request-body-size"
 	FlagTlsMinVersion                  string = "tls-min-version"
 	FlagTlsMaxVersion                  string = "tls-max-version"
@@ -69,12 +95,40 @@ const (
 	DefaultTlsCertKeyPath      string = "/etc/ssl/private/tls.key"
 	DefaultOidcTlsCertPath     string = "/etc/ssl/certs/oidc.crt"
 	DefaultOidcTlsCertKeyPath  string = "/etc/ssl/private/oidc.key"
+	DefaultTracingCACertPath   string = "/etc/ssl/certs/tracing-ca.crt"
+	DefaultTracingCertPath     string = "/etc/ssl/certs/tracing.crt"
+	DefaultTracingCertKeyPath  string = "/etc/ssl/private/tracing.key"
 	DefaultAuthGRPCServicePort int32  = 50051
 	DefaultAuthHTTPServicePort int32  = 5001
 	DefaultOIDCServicePort     int32  = 8083
 	DefaultMetricsServicePort  int32  = 8080
 	DefaultHealthProbePort     int32  = 8081
 
+	DefaultIstioMeshConfigMapNamespace string = "istio-system"
+	DefaultIstioMeshConfigMapName      string = "istio"
+
+	// reason of the Progressing condition of a Deployment whose rollout failed to make progress
+	deploymentProgressDeadlineExceeded = "ProgressDeadlineExceeded"
+
+	oidcServerExposeKindIngress = "Ingress"
+	oidcServerExposeKindRoute   = "Route"
+
+	driftPolicyForce    = "Force"
+	driftPolicyPreserve = "Preserve"
+	// how long the drift overwritten is reported in the DriftDetected condition
+	driftReportSeconds = 600
+
+	// canary defaults
+	defaultCanaryWeight         int32 = 10
+	defaultCanarySoakSeconds    int32 = 300
+	defaultCanaryTimeoutSeconds int32 = 600
+
+	shardingStrategyHash      = "Hash"
+	shardingStrategyNamespace = "Namespace"
+
+	// maximum number of AuthConfigs not ready listed in the status
+	maxNotReadyAuthConfigs = 10
+
 	// status reasons
 	statusProvisioning                            = "Provisioning"
 	statusProvisioned                             = "Provisioned"
@@ -82,6 +136,7 @@ const (
 	statusUnableToCreateServices                  = "UnableToCreateServices"
 	statusUnableToCreateDeployment                = "UnableToCreateDeployment"
 	statusUnableToCreateLeaderElectionRole        = "UnableToCreateLeaderElectionRole"
+	statusUnableToUpdateLeaderElectionRole        = "UnableToUpdateLeaderElectionRole"
 	statusUnableToCreatePermission                = "UnableToCreatePermission"
 	StatusUnableToCreateServiceAccount            = "UnableToCreateServiceAccount"
 	statusUnableToCreateBindingForClusterRole     = "UnableToBindingForClusterRole"
@@ -99,7 +154,33 @@ const (
 	StatusTlsSecretNotProvided                    = "TlsSecretNotProvided"
 	statusUnableToUpdateDeployment                = "UnableToUpdateDeployment"
 	statusDeploymentNotReady                      = "DeploymentNotReady"
+	statusRolloutFailed                           = "RolloutFailed"
+	statusRolledBack                              = "RolledBack"
 	StatusUnableToBuildDeploymentObject           = "UnableToBuildDeploymentObject"
+	statusUnableToExposeOIDCServer                = "UnableToExposeOIDCServer"
+	statusUnableToAttachToGatewayAPI              = "UnableToAttachToGatewayAPI"
+	statusUnableToRegisterIstioExtensionProvider  = "UnableToRegisterIstioExtensionProvider"
+	statusUnableToShardAuthConfigs                = "UnableToShardAuthConfigs"
+	statusUnableToDetectScopeOverlap              = "UnableToDetectScopeOverlap"
+	statusUnableToGetAuthConfigs                  = "UnableToGetAuthConfigs"
+	statusUnableToRemoveResources                 = "UnableToRemoveResources"
+	statusRemoved                                 = "Removed"
+	statusUnableToReconcileCanary                 = "UnableToReconcileCanary"
+	statusUnableToReconcilePodDisruptionBudget    = "UnableToReconcilePodDisruptionBudget"
+	statusUnableToReconcileRBACRules              = "UnableToReconcileRBACRules"
+	statusRBACNotAllowed                          = "RBACNotAllowed"
+	statusCanaryPromoted                          = "CanaryPromoted"
+	statusCanaryRolledBack                        = "CanaryRolledBack"
+	statusDriftDetected                           = "DriftDetected"
+	statusNoDrift                                 = "NoDrift"
+	statusTracingConfigured                       = "TracingConfigured"
+	statusTracingDisabled                         = "TracingDisabled"
+	statusInvalidTracingEndpoint                  = "InvalidTracingEndpoint"
+	statusScopeOverlap                            = "ScopeOverlap"
+	statusNoScopeOverlap                          = "NoScopeOverlap"
+	statusGatewayAPIAttachmentPending             = "Pending"
+	statusGatewayAPIAccepted                      = "Accepted"
+	statusGatewayAPINotAccepted                   = "NotAccepted"
 )
 
 // ldflags
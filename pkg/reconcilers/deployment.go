@@ -2,16 +2,20 @@ package reconcilers
 
 import (
 	"fmt"
+	"maps"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
+	"golang.org/x/mod/semver"
 	k8sapps "k8s.io/api/apps/v1"
 	k8score "k8s.io/api/core/v1"
 	"k8s.io/utils/env"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	api "github.com/kuadrant/authorino-operator/api/v1beta1"
+	"github.com/kuadrant/authorino-operator/pkg/config"
 	authorinoResources "github.com/kuadrant/authorino-operator/pkg/resources"
 )
 
@@ -26,25 +30,23 @@ func IsObjectTaggedToDelete(obj client.Object) bool {
 }
 
 func TagObjectToDelete(obj client.Object) {
-	// Add custom annotation
+	// Add custom annotation (set back, as the annotations of unstructured objects are a copy)
 	annotations := obj.GetAnnotations()
 	if annotations == nil {
 		annotations = make(map[string]string)
-		obj.SetAnnotations(annotations)
 	}
 	annotations[DeleteTagAnnotation] = "true"
+	obj.SetAnnotations(annotations)
 }
 
 func AuthorinoDeployment(authorino *api.Authorino) *k8sapps.Deployment {
+	return authorinoDeployment(authorino, AuthorinoImage(authorino))
+}
+
+func authorinoDeployment(authorino *api.Authorino, image string) *k8sapps.Deployment {
 	var containers []k8score.Container
 	var saName = authorino.Name + "-authorino"
 
-	image := authorino.Spec.Image
-
-	if image == "" {
-		image = env.GetString(RelatedImageAuthorino, DefaultAuthorinoImage)
-	}
-
 	if image == "" {
 		// `DefaultAuthorinoImage can be empty string. But image cannot be or deployment will fail
 		panic("DefaultAuthorinoImage is empty")
@@ -112,6 +114,23 @@ func AuthorinoDeployment(authorino *api.Authorino) *k8sapps.Deployment {
 		volumes = append(volumes, authorinoResources.GetTlsVolume(AuthorinoOidcTlsCertVolumeName, secretName))
 	}
 
+	// mount tls cert volumes for the tracing exporter if set
+	if tls := authorino.Spec.Tracing.Tls; tracingEnabled(authorino) && tls != nil {
+		if tls.CASecret != nil {
+			volumeMounts = append(volumeMounts, k8score.VolumeMount{
+				Name:      AuthorinoTracingCACertVolumeName,
+				MountPath: DefaultTracingCACertPath,
+				SubPath:   "ca.crt",
+				ReadOnly:  true,
+			})
+			volumes = append(volumes, authorinoResources.GetTlsVolume(AuthorinoTracingCACertVolumeName, tls.CASecret.Name))
+		}
+		if tls.ClientCertSecret != nil {
+			volumeMounts = append(volumeMounts, authorinoResources.GetTlsVolumeMount(AuthorinoTracingClientCertVolumeName, DefaultTracingCertPath, DefaultTracingCertKeyPath)...)
+			volumes = append(volumes, authorinoResources.GetTlsVolume(AuthorinoTracingClientCertVolumeName, tls.ClientCertSecret.Name))
+		}
+	}
+
 	args := buildAuthorinoArgs(authorino)
 	var envs []k8score.EnvVar
 
@@ -131,9 +150,23 @@ func AuthorinoDeployment(authorino *api.Authorino) *k8sapps.Deployment {
 		args = compatibleArgs
 	}
 
+	// flags not in every Authorino version are only passed to the versions that have them
+	args = slices.DeleteFunc(args, func(arg string) bool {
+		return !authorinoFlagSupported(argFlag(arg), authorinoVersion)
+	})
+
+	// tls, sampling and headers of the tracing exporter, read by the OpenTelemetry SDK from the env (Authorino has no
+	// flags for them)
+	if tracingEnabled(authorino) {
+		envs = append(envs, buildTracingEnv(authorino.Spec.Tracing)...)
+	}
+
 	// generates the Container where authorino will be running
 	// adds to the list of containers available in the deployment
 	authorinoContainer := authorinoResources.GetContainer(image, authorino.Spec.ImagePullPolicy, AuthorinoContainerName, args, envs, volumeMounts)
+	if resources := config.Current().DefaultResources; resources != nil {
+		authorinoContainer.Resources = *resources.DeepCopy()
+	}
 	containers = append(containers, authorinoContainer)
 	replicas := authorino.Spec.Replicas
 	if replicas == nil {
@@ -151,6 +184,27 @@ func AuthorinoDeployment(authorino *api.Authorino) *k8sapps.Deployment {
 		volumes,
 		authorino.Labels,
 	)
+	// the pods of the canary Deployment are otherwise selected too
+	deployment.Spec.Selector.MatchLabels[CanaryLabel] = "false"
+	deployment.Spec.Template.Labels[CanaryLabel] = "false"
+
+	if rollout := authorino.Spec.Rollout; rollout != nil {
+		if rollout.RollingUpdate != nil {
+			deployment.Spec.Strategy = k8sapps.DeploymentStrategy{
+				Type:          k8sapps.RollingUpdateDeploymentStrategyType,
+				RollingUpdate: rollout.RollingUpdate.DeepCopy(),
+			}
+		}
+		if rollout.MinReadySeconds != nil {
+			deployment.Spec.MinReadySeconds = *rollout.MinReadySeconds
+		}
+		deployment.Spec.ProgressDeadlineSeconds = rollout.ProgressDeadlineSeconds
+	}
+
+	if authorino.Spec.HighAvailability {
+		deployment.Spec.Template.Spec.TopologySpreadConstraints = authorinoResources.GetTopologySpreadConstraints(authorino.Name)
+		deployment.Spec.Template.Spec.Affinity = authorinoResources.GetPodAntiAffinity(authorino.Name)
+	}
 
 	return deployment
 }
@@ -164,7 +218,7 @@ func buildAuthorinoArgs(authorino *api.Authorino) []string {
 	}
 
 	// auth-config-label-selector
-	if selectors := authorino.Spec.AuthConfigLabelSelectors; selectors != "" {
+	if selectors := AuthConfigLabelSelectors(authorino); selectors != "" {
 		args = append(args, fmt.Sprintf("--%s=%s", FlagWatchedAuthConfigLabelSelector, selectors))
 	}
 
@@ -188,6 +242,20 @@ func buildAuthorinoArgs(authorino *api.Authorino) []string {
 		args = append(args, fmt.Sprintf("--%s=%s", FlagLogMode, logMode))
 	}
 
+	// log-encoder, log-time-format and log-sampling
+	if logging := authorino.Spec.Logging; logging != nil {
+		if logging.Encoder != "" {
+			args = append(args, fmt.Sprintf("--%s=%s", FlagLogEncoder, logging.Encoder))
+		}
+		if logging.TimeFormat != "" {
+			args = append(args, fmt.Sprintf("--%s=%s", FlagLogTimeFormat, logging.TimeFormat))
+		}
+		if sampling := logging.Sampling; sampling != nil {
+			args = append(args, fmt.Sprintf("--%s=%d", FlagLogSamplingInitial, sampling.Initial))
+			args = append(args, fmt.Sprintf("--%s=%d", FlagLogSamplingThereafter, sampling.Thereafter))
+		}
+	}
+
 	// timeout
 	if timeout := authorino.Spec.Listener.Timeout; timeout != nil {
 		args = append(args, fmt.Sprintf("--%s=%d", FlagTimeout, *timeout))
@@ -247,13 +315,16 @@ func buildAuthorinoArgs(authorino *api.Authorino) []string {
 		args = append(args, fmt.Sprintf("--%s=%d", FlagEvaluatorCacheSize, *evaluatorCacheSize))
 	}
 
-	// tracing-service-endpoint, tracing-service-tag, and tracing-service-insecure
-	if tracingServiceEndpoint := authorino.Spec.Tracing.Endpoint; tracingServiceEndpoint != "" {
+	// tracing-service-endpoint, tracing-service-tag, tracing-service-insecure (an invalid endpoint is left out, so
+	// Authorino does not fail to start, and reported in the TracingConfigured condition; the tls, sampling and headers
+	// are passed in the env, see buildTracingEnv)
+	if tracingServiceEndpoint := authorino.Spec.Tracing.Endpoint; tracingEnabled(authorino) {
+		tracing := authorino.Spec.Tracing
 		args = append(args, fmt.Sprintf("--%s=%s", FlagTracingServiceEndpoint, tracingServiceEndpoint))
-		for key, value := range authorino.Spec.Tracing.Tags {
-			args = append(args, fmt.Sprintf(`--%s=%s=%s`, FlagTracingServiceTag, key, value))
+		for _, key := range slices.Sorted(maps.Keys(tracing.Tags)) {
+			args = append(args, fmt.Sprintf(`--%s=%s=%s`, FlagTracingServiceTag, key, tracing.Tags[key]))
 		}
-		if authorino.Spec.Tracing.Insecure {
+		if tracing.Insecure {
 			args = append(args, fmt.Sprintf(`--%s`, FlagTracingServiceInsecure))
 		}
 	}
@@ -273,9 +344,24 @@ func buildAuthorinoArgs(authorino *api.Authorino) []string {
 		args = append(args, fmt.Sprintf("--%s=:%d", FlagHealthProbeAddr, *port))
 	}
 
-	// enable-leader-election
-	if replicas := authorino.Spec.Replicas; replicas != nil && *replicas > 1 {
+	// enable-leader-election, leader-election-lease-duration, leader-election-renew-deadline,
+	// leader-election-retry-period and leader-election-id
+	if leaderElectionEnabled(authorino) {
 		args = append(args, fmt.Sprintf("--%s", FlagEnableLeaderElection))
+		if leaderElection := authorino.Spec.LeaderElection; leaderElection != nil {
+			if leaderElection.LeaseDuration != nil {
+				args = append(args, fmt.Sprintf("--%s=%s", FlagLeaderElectionLeaseDuration, leaderElection.LeaseDuration.Duration))
+			}
+			if leaderElection.RenewDeadline != nil {
+				args = append(args, fmt.Sprintf("--%s=%s", FlagLeaderElectionRenewDeadline, leaderElection.RenewDeadline.Duration))
+			}
+			if leaderElection.RetryPeriod != nil {
+				args = append(args, fmt.Sprintf("--%s=%s", FlagLeaderElectionRetryPeriod, leaderElection.RetryPeriod.Duration))
+			}
+			if leaderElection.LeaseLockName != "" {
+				args = append(args, fmt.Sprintf("--%s=%s", FlagLeaderElectionID, leaderElection.LeaseLockName))
+			}
+		}
 	}
 
 	// max-http-request-body-size
@@ -286,6 +372,96 @@ func buildAuthorinoArgs(authorino *api.Authorino) []string {
 	return args
 }
 
+// leaderElectionEnabled tells whether the Authorino replicas elect a leader, as set in the spec or else when there is
+// more than one replica, also while running a canary, whose pods are replicas of the same instance
+func leaderElectionEnabled(authorino *api.Authorino) bool {
+	if leaderElection := authorino.Spec.LeaderElection; leaderElection != nil && leaderElection.Enabled != nil {
+		return *leaderElection.Enabled
+	}
+	replicas := authorino.Spec.Replicas
+	return (replicas != nil && *replicas > 1) || authorino.Spec.Canary != nil
+}
+
+// authorinoFlagReleases maps the flags built from the spec that not every Authorino version has to the Authorino
+// release that added them. Empty for the flags of no Authorino release yet.
+var authorinoFlagReleases = map[string]string{
+	FlagLogEncoder:            "",
+	FlagLogTimeFormat:         "",
+	FlagLogSamplingInitial:    "",
+	FlagLogSamplingThereafter: "",
+
+	FlagLeaderElectionLeaseDuration: "",
+	FlagLeaderElectionRenewDeadline: "",
+	FlagLeaderElectionRetryPeriod:   "",
+	FlagLeaderElectionID:            "",
+}
+
+// authorinoFlagSupported tells whether the version of Authorino, out of the tag of its image, has the flag. Authorino
+// fails to start on unknown flags, so the flags added by a release are only supported by tags that are semantic
+// versions at or after the release; other tags (e.g. latest) may be any version.
+func authorinoFlagSupported(flag, version string) bool {
+	release, gated := authorinoFlagReleases[flag]
+	if !gated {
+		return true
+	}
+	return release != "" && semver.IsValid(version) && semver.Compare(version, release) >= 0
+}
+
+// argFlag returns the name of the flag of the arg
+func argFlag(arg string) string {
+	return strings.SplitN(strings.TrimPrefix(arg, "--"), "=", 2)[0]
+}
+
+// buildTracingEnv builds the env vars of the OpenTelemetry SDK with the tls, sampling and headers of the tracing
+// exporter. Unlike flags, env vars unknown to an Authorino version do not fail its startup.
+// The values of the headers are read from the Secrets into other env vars, so they do not show in the Deployment.
+func buildTracingEnv(tracing api.Tracing) []k8score.EnvVar {
+	var envs []k8score.EnvVar
+
+	if tls := tracing.Tls; tls != nil {
+		if tls.CASecret != nil {
+			envs = append(envs, k8score.EnvVar{Name: EnvOtelExporterTracesCertificate, Value: DefaultTracingCACertPath})
+		}
+		if tls.ClientCertSecret != nil {
+			envs = append(envs, k8score.EnvVar{Name: EnvOtelExporterTracesClientCertificate, Value: DefaultTracingCertPath})
+			envs = append(envs, k8score.EnvVar{Name: EnvOtelExporterTracesClientKey, Value: DefaultTracingCertKeyPath})
+		}
+	}
+
+	if sampling := tracing.Sampling; sampling != nil {
+		sampler := "always_on"
+		if sampling.Ratio != "" {
+			sampler = "traceidratio"
+		}
+		// parent based by default, as the sampler of the OpenTelemetry SDK
+		if sampling.ParentBased == nil || *sampling.ParentBased {
+			sampler = "parentbased_" + sampler
+		}
+		envs = append(envs, k8score.EnvVar{Name: EnvOtelTracesSampler, Value: sampler})
+		if sampling.Ratio != "" {
+			envs = append(envs, k8score.EnvVar{Name: EnvOtelTracesSamplerArg, Value: sampling.Ratio})
+		}
+	}
+
+	if len(tracing.Headers) > 0 {
+		var headers []string
+		for i, header := range tracing.Headers {
+			envs = append(envs, k8score.EnvVar{
+				Name:      tracingHeaderEnvVarName(i),
+				ValueFrom: &k8score.EnvVarSource{SecretKeyRef: header.ValueFrom.DeepCopy()},
+			})
+			headers = append(headers, fmt.Sprintf("%s=$(%s)", header.Name, tracingHeaderEnvVarName(i)))
+		}
+		envs = append(envs, k8score.EnvVar{Name: EnvOtelExporterTracesHeaders, Value: strings.Join(headers, ",")})
+	}
+
+	return envs
+}
+
+func tracingHeaderEnvVarName(index int) string {
+	return fmt.Sprintf("%s_%d", EnvTracingServiceHeader, index)
+}
+
 // Deprecated: Configures Authorino by defining environment variables (instead of command-line args)
 // Kept for backward compatibility with older versions of Authorino (<= v0.10.x)
 func buildAuthorinoEnv(authorino *api.Authorino) []k8score.EnvVar {
@@ -298,7 +474,7 @@ func buildAuthorinoEnv(authorino *api.Authorino) []k8score.EnvVar {
 		})
 	}
 
-	if v := authorino.Spec.AuthConfigLabelSelectors; v != "" {
+	if v := AuthConfigLabelSelectors(authorino); v != "" {
 		envVar = append(envVar, k8score.EnvVar{
 			Name:  EnvAuthConfigLabelSelector,
 			Value: v,
@@ -426,6 +602,53 @@ func authorinoVersionFromImageTag(image string) string {
 	return parts[len(parts)-1]
 }
 
+// AuthorinoImage returns the Authorino image of the instance, defaulting to the image of the operator configuration,
+// then to the image related to the operator
+func AuthorinoImage(authorino *api.Authorino) string {
+	if authorino.Spec.Image != "" {
+		return authorino.Spec.Image
+	}
+	if image := config.Current().DefaultAuthorinoImage; image != "" {
+		return image
+	}
+	return env.GetString(RelatedImageAuthorino, DefaultAuthorinoImage)
+}
+
+// AuthServiceEnabled tells whether the authorization server is exposed by a Service
+func AuthServiceEnabled(authorino *api.Authorino) bool {
+	enabled := authorino.Spec.Listener.Service.Enabled
+	return enabled == nil || *enabled
+}
+
+// OIDCServerEnabled tells whether the OIDC server is exposed by a Service
+func OIDCServerEnabled(authorino *api.Authorino) bool {
+	enabled := authorino.Spec.OIDCServer.Service.Enabled
+	return enabled == nil || *enabled
+}
+
+// DeploymentRolloutFailed tells whether the rollout of the Deployment failed to make progress within its progress
+// deadline, returning the reason reported by the Deployment controller
+func DeploymentRolloutFailed(deployment *k8sapps.Deployment) (bool, string) {
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == k8sapps.DeploymentProgressing && condition.Status == k8score.ConditionFalse && condition.Reason == deploymentProgressDeadlineExceeded {
+			return true, condition.Message
+		}
+	}
+	return false, ""
+}
+
+// DeploymentRolledOut tells whether all the replicas of the Deployment are updated to its current revision and
+// available, which is stricter than the Available condition, satisfied within the maximum number of unavailable pods
+func DeploymentRolledOut(deployment *k8sapps.Deployment) bool {
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	return deployment.Status.ObservedGeneration >= deployment.Generation &&
+		deployment.Status.UpdatedReplicas >= replicas &&
+		deployment.Status.AvailableReplicas >= replicas
+}
+
 func DeploymentAvailable(deployment *k8sapps.Deployment) bool {
 	for _, condition := range deployment.Status.Conditions {
 		switch condition.Type {
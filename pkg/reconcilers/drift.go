@@ -0,0 +1,309 @@
+package reconcilers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	k8score "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/structured-merge-diff/v6/fieldpath"
+	"sigs.k8s.io/structured-merge-diff/v6/value"
+
+	api "github.com/kuadrant/authorino-operator/api/v1beta1"
+	"github.com/kuadrant/authorino-operator/pkg/condition"
+)
+
+type driftReportKey struct{}
+
+// driftReport collects the fields of the resources of an Authorino instance modified by other field managers (e.g.
+// by hand), over a reconciliation of the instance
+type driftReport struct {
+	policy string
+	drifts []string
+}
+
+// WithDriftReport returns a context in which the resources applied are checked for drift, according to the drift
+// policy of the Authorino instance. The drift is reported by ReconcileAuthorinoDriftStatus.
+func WithDriftReport(ctx context.Context, authorino *api.Authorino) context.Context {
+	return context.WithValue(ctx, driftReportKey{}, &driftReport{policy: authorino.Spec.DriftPolicy})
+}
+
+func driftReportFromContext(ctx context.Context) *driftReport {
+	report, _ := ctx.Value(driftReportKey{}).(*driftReport)
+	return report
+}
+
+// force tells whether the fields modified by other field managers are overwritten
+func (d *driftReport) force() bool {
+	return d.policy != driftPolicyPreserve
+}
+
+// ownFieldManagers are the field managers of the operator: its own, and the one recorded by Server-Side Apply for the
+// fields set before the first apply.
+// The resources created by former versions of the operator, which did not set the field manager, also have fields
+// managed by "manager", the default field manager of the controllers built with kubebuilder, other than the operator
+// too. Those fields are only co-owned by the operator if they were not modified since, so they are not drift (see
+// driftedFields).
+var ownFieldManagers = []string{fieldOwner, "before-first-apply"}
+
+// applyResourceReportingDrift applies the desired state of a resource, checking the managedFields of the live resource
+// first for the fields managed by other field managers with values other than the desired ones. Those are collected in
+// the drift report and overwritten, unless the drift policy preserves them, in which case they are left out of the
+// desired state applied.
+func (r *AuthorinoReconciler) applyResourceReportingDrift(ctx context.Context, live, desired client.Object, report *driftReport) error {
+	logger, err := logr.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	drifted, preserved, err := driftedFields(live, desired)
+	if err != nil {
+		return err
+	}
+	if len(drifted) == 0 {
+		return r.ApplyResource(ctx, desired)
+	}
+
+	gvk, err := apiutil.GVKForObject(desired, r.Scheme)
+	if err != nil {
+		return err
+	}
+	report.drifts = append(report.drifts, fmt.Sprintf("%s %s: %s", gvk.Kind, client.ObjectKeyFromObject(desired), strings.Join(drifted, ", ")))
+
+	if report.force() {
+		return r.ApplyResource(ctx, desired)
+	}
+	logger.Info("preserving fields modified by other managers", "kind", gvk.Kind, "name", desired.GetName(), "fields", drifted)
+	preserved.SetGroupVersionKind(gvk)
+	return r.ApplyResource(ctx, preserved)
+}
+
+// driftedFields compares the desired state of a resource with the live one, field by field, for the fields of the
+// live resource managed by field managers other than the operator only. It returns the fields with values other than
+// the desired ones, as "<field path> (<manager>)", and the desired state without them.
+// The fields also managed by the operator are not drift, as the field managers that modify a field take it over.
+func driftedFields(live, desired client.Object) ([]string, *unstructured.Unstructured, error) {
+	liveContent, err := jsonContent(live)
+	if err != nil {
+		return nil, nil, err
+	}
+	desiredContent, err := jsonContent(desired)
+	if err != nil {
+		return nil, nil, err
+	}
+	preserved := &unstructured.Unstructured{Object: runtime.DeepCopyJSON(desiredContent)}
+
+	owned := &fieldpath.Set{}
+	others := map[string]*fieldpath.Set{}
+	for _, entry := range live.GetManagedFields() {
+		if entry.Subresource != "" || entry.FieldsV1 == nil {
+			continue
+		}
+		fields := &fieldpath.Set{}
+		if err := fields.FromJSON(bytes.NewReader(entry.FieldsV1.Raw)); err != nil {
+			return nil, nil, fmt.Errorf("failed to read the fields managed by %s: %v", entry.Manager, err)
+		}
+		if slices.Contains(ownFieldManagers, entry.Manager) {
+			owned = owned.Union(fields)
+		} else if managed, ok := others[entry.Manager]; ok {
+			others[entry.Manager] = managed.Union(fields)
+		} else {
+			others[entry.Manager] = fields
+		}
+	}
+
+	var drifted []string
+	for manager, fields := range others {
+		fields.Leaves().Iterate(func(path fieldpath.Path) {
+			if owned.Has(path) {
+				return
+			}
+			desiredValue, found := fieldValue(desiredContent, path)
+			if !found {
+				return // not managed by the operator
+			}
+			if liveValue, _ := fieldValue(liveContent, path); reflect.DeepEqual(desiredValue, liveValue) {
+				return
+			}
+			drifted = append(drifted, fmt.Sprintf("%s (%s)", path, manager))
+			removeField(preserved.Object, path)
+		})
+	}
+	slices.Sort(drifted)
+
+	return drifted, preserved, nil
+}
+
+// jsonContent returns the content of an object as decoded from JSON, so values of typed and unstructured objects
+// compare equal (e.g. all numbers are float64)
+func jsonContent(obj interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	content := map[string]interface{}{}
+	return content, json.Unmarshal(data, &content)
+}
+
+// fieldValue returns the value of a field of the content of an object, and whether it is set
+func fieldValue(content interface{}, path fieldpath.Path) (interface{}, bool) {
+	for _, element := range path {
+		var found bool
+		if content, _, found = childValue(content, element); !found {
+			return nil, false
+		}
+	}
+	return content, true
+}
+
+// removeField unsets a field of the content of an object, if set
+func removeField(content interface{}, path fieldpath.Path) {
+	if len(path) == 0 {
+		return
+	}
+	parent, found := fieldValue(content, path[:len(path)-1])
+	if !found {
+		return
+	}
+	element := path[len(path)-1]
+	if element.FieldName != nil {
+		if m, ok := parent.(map[string]interface{}); ok {
+			delete(m, *element.FieldName)
+		}
+		return
+	}
+	if _, index, found := childValue(parent, element); found {
+		list := parent.([]interface{})
+		grandparent, _ := fieldValue(content, path[:len(path)-2])
+		if m, ok := grandparent.(map[string]interface{}); ok && path[len(path)-2].FieldName != nil {
+			m[*path[len(path)-2].FieldName] = slices.Delete(list, index, index+1)
+		}
+	}
+}
+
+// childValue returns the value of a map field or of a list item selected by a path element, with the index of the
+// list item, and whether it is set
+func childValue(content interface{}, element fieldpath.PathElement) (interface{}, int, bool) {
+	if element.FieldName != nil {
+		m, ok := content.(map[string]interface{})
+		if !ok {
+			return nil, 0, false
+		}
+		child, found := m[*element.FieldName]
+		return child, 0, found
+	}
+
+	list, ok := content.([]interface{})
+	if !ok {
+		return nil, 0, false
+	}
+	if element.Index != nil {
+		if *element.Index < 0 || *element.Index >= len(list) {
+			return nil, 0, false
+		}
+		return list[*element.Index], *element.Index, true
+	}
+	for i, item := range list {
+		switch {
+		case element.Value != nil:
+			if v, err := jsonValue((*element.Value).Unstructured()); err == nil && reflect.DeepEqual(item, v) {
+				return item, i, true
+			}
+		case element.Key != nil:
+			m, ok := item.(map[string]interface{})
+			if ok && slices.IndexFunc(*element.Key, func(key value.Field) bool {
+				v, err := jsonValue(key.Value.Unstructured())
+				return err != nil || !reflect.DeepEqual(m[key.Name], v)
+			}) < 0 {
+				return item, i, true
+			}
+		}
+	}
+	return nil, 0, false
+}
+
+// jsonValue returns a value as decoded from JSON
+func jsonValue(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out interface{}
+	return out, json.Unmarshal(data, &out)
+}
+
+// ReconcileAuthorinoDriftStatus reports the drift collected over the reconciliation of the Authorino instance in the
+// DriftDetected condition, with a Warning event when new drift is detected.
+// The drift overwritten is still reported for driftReportSeconds, as the reconciliations right after overwriting it,
+// triggered by the changes applied, find no drift left.
+func (r *AuthorinoReconciler) ReconcileAuthorinoDriftStatus(ctx context.Context, authorinoInstance *api.Authorino) error {
+	report := driftReportFromContext(ctx)
+	if report == nil {
+		return nil
+	}
+
+	drift := api.Condition{
+		Type:   api.ConditionDriftDetected,
+		Status: k8score.ConditionFalse,
+		Reason: statusNoDrift,
+	}
+	if len(report.drifts) > 0 {
+		drift.Status = k8score.ConditionTrue
+		drift.Reason = statusDriftDetected
+		drift.Message = fmt.Sprintf("fields modified by other managers: %s", strings.Join(report.drifts, "; "))
+		if report.force() {
+			// the drift overwritten again is reported again, even if the same as the last one
+			now := metav1.Now()
+			drift.LastUpdatedTime = &now
+		}
+	} else if DriftRequeueAfter(authorinoInstance) > 0 {
+		return nil
+	}
+
+	if _, changed := condition.AddOrUpdateStatusConditions(authorinoInstance.Status.Conditions, drift); !changed {
+		return nil
+	}
+
+	if drift.Status == k8score.ConditionTrue && r.Recorder != nil {
+		action := "overwritten"
+		if !report.force() {
+			action = "preserved"
+		}
+		r.Recorder.Eventf(authorinoInstance, nil, k8score.EventTypeWarning, statusDriftDetected, "DetectDrift",
+			"Resources of the Authorino instance modified outside of the operator (%s), %s", action, drift.Message)
+	}
+
+	return r.updateStatusConditions(authorinoInstance, drift)
+}
+
+// DriftRequeueAfter returns when the drift overwritten last, still reported in the DriftDetected condition, is due to be
+// cleared. Zero means no drift overwritten reported.
+func DriftRequeueAfter(authorino *api.Authorino) time.Duration {
+	if authorino.Spec.DriftPolicy == driftPolicyPreserve {
+		return 0 // the drift preserved is reported for as long as it lasts
+	}
+	for _, cond := range authorino.Status.Conditions {
+		if cond.Type != api.ConditionDriftDetected || cond.Status != k8score.ConditionTrue {
+			continue
+		}
+		detected := cond.LastTransitionTime.Time
+		if cond.LastUpdatedTime != nil {
+			detected = cond.LastUpdatedTime.Time
+		}
+		if after := time.Until(detected.Add(driftReportSeconds * time.Second)); after > 0 {
+			return after
+		}
+	}
+	return 0
+}
@@ -0,0 +1,193 @@
+package reconcilers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	k8score "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	api "github.com/kuadrant/authorino-operator/api/v1beta1"
+	"github.com/kuadrant/authorino-operator/pkg/condition"
+	authorinoResources "github.com/kuadrant/authorino-operator/pkg/resources"
+)
+
+var gatewayAPIKinds = []schema.GroupVersionKind{
+	authorinoResources.GRPCRouteGVK,
+	authorinoResources.HTTPRouteGVK,
+	authorinoResources.SecurityPolicyGVK,
+}
+
+// ReconcileAuthorinoGatewayAPIIntegration attaches the auth service to the Gateway API by generating a GRPCRoute, an
+// HTTPRoute or an Envoy Gateway SecurityPolicy, and reports whether the parents of the object accepted it.
+// Objects of the kinds not selected in the spec are deleted.
+func (r *AuthorinoReconciler) ReconcileAuthorinoGatewayAPIIntegration(ctx context.Context, authorinoInstance *api.Authorino) error {
+	logger, err := logr.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	integration := authorinoInstance.Spec.Integration.GatewayAPI
+
+	// the generated objects route to the auth Service
+	if integration != nil && !AuthServiceEnabled(authorinoInstance) {
+		return r.WrapErrorWithStatusUpdate(logger, authorinoInstance, r.SetStatusFailed(statusUnableToAttachToGatewayAPI),
+			fmt.Errorf("failed to attach %s to the Gateway API, the auth Service is disabled", authorinoInstance.Name))
+	}
+
+	var attached *api.Condition
+
+	for _, gvk := range gatewayAPIKinds {
+		kind := gvk.Kind
+		available, err := r.apiAvailable(gvk)
+		if err != nil {
+			return r.WrapErrorWithStatusUpdate(logger, authorinoInstance, r.SetStatusFailed(statusUnableToAttachToGatewayAPI),
+				fmt.Errorf("failed to discover the %s API, err: %v", kind, err))
+		}
+
+		selected := integration != nil && integration.Kind == kind
+
+		if !available {
+			if selected {
+				return r.WrapErrorWithStatusUpdate(logger, authorinoInstance, r.SetStatusFailed(statusUnableToAttachToGatewayAPI),
+					fmt.Errorf("failed to attach %s to the Gateway API, the %s API is not available in the cluster", authorinoInstance.Name, kind))
+			}
+			continue
+		}
+
+		desired := gatewayAPIObject(authorinoInstance, gvk)
+
+		if err := ctrl.SetControllerReference(authorinoInstance, desired, r.Scheme); err != nil {
+			return err
+		}
+
+		existing := &unstructured.Unstructured{}
+		existing.SetGroupVersionKind(gvk)
+		crud, obj, err := r.reconcileResource(ctx, existing, desired)
+		if err != nil {
+			return r.WrapErrorWithStatusUpdate(logger, authorinoInstance, r.SetStatusFailed(statusUnableToAttachToGatewayAPI),
+				fmt.Errorf("failed to %s %s %s, err: %v", crud, kind, desired.GetName(), err))
+		}
+
+		if selected {
+			current, _ := obj.(*unstructured.Unstructured)
+			cond := gatewayAPIAttachedCondition(kind, current)
+			attached = &cond
+		}
+	}
+
+	if attached == nil {
+		conditions, updated := condition.RemoveStatusConditions(authorinoInstance.Status.Conditions, api.ConditionGatewayAPIAttached)
+		if !updated {
+			return nil
+		}
+		authorinoInstance.Status.Conditions = conditions
+		return r.updateStatusConditions(authorinoInstance)
+	}
+
+	return r.updateStatusConditions(authorinoInstance, *attached)
+}
+
+// gatewayAPIObject builds the Gateway API object of the kind for the Authorino instance, tagged to delete unless the
+// kind is the one selected in the spec
+func gatewayAPIObject(authorino *api.Authorino, gvk schema.GroupVersionKind) *unstructured.Unstructured {
+	integration := authorino.Spec.Integration.GatewayAPI
+	selected := integration != nil && integration.Kind == gvk.Kind
+
+	var obj *unstructured.Unstructured
+	switch gvk {
+	case authorinoResources.SecurityPolicyGVK:
+		var targetRefs []api.GatewayAPIReference
+		if selected {
+			targetRefs = integration.TargetRefs
+		}
+		obj = authorinoResources.NewAuthSecurityPolicy(authorino.Name, authorino.Namespace, authGRPCServicePort(authorino), targetRefs, authorino.Labels)
+	default:
+		var parentRefs []api.GatewayAPIReference
+		var hostnames []string
+		if selected {
+			parentRefs = integration.ParentRefs
+			hostnames = integration.Hostnames
+		}
+		port := authGRPCServicePort(authorino)
+		if gvk == authorinoResources.HTTPRouteGVK {
+			port = authHTTPServicePort(authorino)
+		}
+		obj = authorinoResources.NewAuthRoute(gvk, authorino.Name, authorino.Namespace, port, parentRefs, hostnames, authorino.Labels)
+	}
+
+	if !selected {
+		TagObjectToDelete(obj)
+	}
+	return obj
+}
+
+// gatewayAPIAttachedCondition builds the GatewayAPIAttached condition out of the Accepted conditions reported by the
+// parents of a Gateway API object
+func gatewayAPIAttachedCondition(kind string, obj *unstructured.Unstructured) api.Condition {
+	var statuses []interface{}
+	if obj != nil {
+		statuses = authorinoResources.GatewayAPIAncestorStatuses(obj)
+	}
+
+	if len(statuses) == 0 {
+		return api.Condition{
+			Type:    api.ConditionGatewayAPIAttached,
+			Status:  k8score.ConditionUnknown,
+			Reason:  statusGatewayAPIAttachmentPending,
+			Message: fmt.Sprintf("%s not yet accepted by any parent", kind),
+		}
+	}
+
+	var notAccepted []string
+	for _, s := range statuses {
+		status, ok := s.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		ref, _, _ := unstructured.NestedStringMap(status, "parentRef")
+		if ref == nil {
+			ref, _, _ = unstructured.NestedStringMap(status, "ancestorRef")
+		}
+		parent := ref["name"]
+		if ns := ref["namespace"]; ns != "" {
+			parent = ns + "/" + parent
+		}
+
+		accepted := false
+		reason := "Pending"
+		conditions, _, _ := unstructured.NestedSlice(status, "conditions")
+		for _, c := range conditions {
+			cond, ok := c.(map[string]interface{})
+			if !ok || cond["type"] != "Accepted" {
+				continue
+			}
+			accepted = cond["status"] == string(k8score.ConditionTrue)
+			if r, ok := cond["reason"].(string); ok && r != "" {
+				reason = r
+			}
+		}
+		if !accepted {
+			notAccepted = append(notAccepted, fmt.Sprintf("%s (%s)", parent, reason))
+		}
+	}
+
+	if len(notAccepted) > 0 {
+		return api.Condition{
+			Type:    api.ConditionGatewayAPIAttached,
+			Status:  k8score.ConditionFalse,
+			Reason:  statusGatewayAPINotAccepted,
+			Message: fmt.Sprintf("%s not accepted by: %s", kind, strings.Join(notAccepted, ", ")),
+		}
+	}
+
+	return api.Condition{
+		Type:   api.ConditionGatewayAPIAttached,
+		Status: k8score.ConditionTrue,
+		Reason: statusGatewayAPIAccepted,
+	}
+}
@@ -0,0 +1,173 @@
+package reconcilers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	k8score "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/kuadrant/authorino-operator/api/v1beta1"
+	"github.com/kuadrant/authorino-operator/pkg/config"
+	authorinoResources "github.com/kuadrant/authorino-operator/pkg/resources"
+)
+
+// ReconcileAuthorinoIstioIntegration registers the auth service as an envoyExtAuthzGrpc extension provider in the
+// Istio mesh config and, optionally, generates an AuthorizationPolicy with CUSTOM action that uses the provider.
+// The registered provider is recorded in the status of the Authorino CR, so it can be removed later on, when the
+// integration is disabled or moved, or the Authorino CR is deleted.
+func (r *AuthorinoReconciler) ReconcileAuthorinoIstioIntegration(ctx context.Context, authorinoInstance *api.Authorino) error {
+	logger, err := logr.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	istio := authorinoInstance.Spec.Integration.Istio
+
+	// the extension provider points at the auth Service
+	if istio != nil && !AuthServiceEnabled(authorinoInstance) {
+		return r.WrapErrorWithStatusUpdate(logger, authorinoInstance, r.SetStatusFailed(statusUnableToRegisterIstioExtensionProvider),
+			fmt.Errorf("failed to register %s as an Istio extension provider, the auth Service is disabled", authorinoInstance.Name))
+	}
+
+	var desired *api.IstioExtensionProviderStatus
+	if istio != nil {
+		desired = &api.IstioExtensionProviderStatus{
+			Name:          authorinoResources.IstioExtensionProviderName(authorinoInstance.Namespace, authorinoInstance.Name),
+			MeshConfigMap: istioMeshConfigMap(),
+		}
+	}
+
+	// remove the extension provider registered previously, if no longer wanted where it is
+	if current := authorinoInstance.Status.IstioExtensionProvider; current != nil && (desired == nil || *current != *desired) {
+		if err := r.removeIstioExtensionProvider(ctx, current, authorinoInstance); err != nil {
+			return r.WrapErrorWithStatusUpdate(logger, authorinoInstance, r.SetStatusFailed(statusUnableToRegisterIstioExtensionProvider),
+				fmt.Errorf("failed to remove extension provider %s from the Istio mesh config, err: %v", current.Name, err))
+		}
+	}
+
+	if desired != nil {
+		provider := authorinoResources.NewIstioExtensionProvider(authorinoInstance.Name, authorinoInstance.Namespace, authGRPCServicePort(authorinoInstance))
+		if err := r.updateIstioMeshConfig(ctx, desired.MeshConfigMap, func(meshConfig string) (string, bool, error) {
+			return authorinoResources.UpsertIstioExtensionProvider(meshConfig, provider)
+		}); err != nil {
+			return r.WrapErrorWithStatusUpdate(logger, authorinoInstance, r.SetStatusFailed(statusUnableToRegisterIstioExtensionProvider),
+				fmt.Errorf("failed to register extension provider %s in the Istio mesh config, err: %v", desired.Name, err))
+		}
+	}
+
+	// authorization policy
+	authorizationPolicyAPIAvailable, err := r.apiAvailable(authorinoResources.AuthorizationPolicyGVK)
+	if err != nil {
+		return r.WrapErrorWithStatusUpdate(logger, authorinoInstance, r.SetStatusFailed(statusUnableToRegisterIstioExtensionProvider),
+			fmt.Errorf("failed to discover the Istio AuthorizationPolicy API, err: %v", err))
+	}
+	wantAuthorizationPolicy := istio != nil && istio.AuthorizationPolicy != nil
+	if wantAuthorizationPolicy && !authorizationPolicyAPIAvailable {
+		return r.WrapErrorWithStatusUpdate(logger, authorinoInstance, r.SetStatusFailed(statusUnableToRegisterIstioExtensionProvider),
+			fmt.Errorf("failed to generate the AuthorizationPolicy of %s, the Istio AuthorizationPolicy API is not available in the cluster", authorinoInstance.Name))
+	}
+	if authorizationPolicyAPIAvailable {
+		policy := istioAuthorizationPolicy(authorinoInstance)
+		if err := ctrl.SetControllerReference(authorinoInstance, policy, r.Scheme); err != nil {
+			return err
+		}
+		existing := &unstructured.Unstructured{}
+		existing.SetGroupVersionKind(authorinoResources.AuthorizationPolicyGVK)
+		if crud, _, err := r.reconcileResource(ctx, existing, policy); err != nil {
+			return r.WrapErrorWithStatusUpdate(logger, authorinoInstance, r.SetStatusFailed(statusUnableToRegisterIstioExtensionProvider),
+				fmt.Errorf("failed to %s AuthorizationPolicy %s, err: %v", crud, policy.GetName(), err))
+		}
+	}
+
+	current := authorinoInstance.Status.IstioExtensionProvider
+	if (current == nil) != (desired == nil) || (current != nil && *current != *desired) {
+		authorinoInstance.Status.IstioExtensionProvider = desired
+		return r.updateStatusConditions(authorinoInstance)
+	}
+
+	return nil
+}
+
+// CleanupIstioExtensionProvider removes the extension provider registered for the Authorino instance from the Istio
+// mesh config, and from the status of the instance. The AuthorizationPolicy is garbage collected by k8s because of the
+// owner reference.
+// It is best-effort: failures are logged rather than surfaced, so they don't block the deletion of the Authorino CR.
+func (r *AuthorinoReconciler) CleanupIstioExtensionProvider(ctx context.Context, authorinoInstance *api.Authorino) {
+	current := authorinoInstance.Status.IstioExtensionProvider
+	if current == nil {
+		return
+	}
+
+	if err := r.removeIstioExtensionProvider(ctx, current, authorinoInstance); err != nil {
+		r.Log.Error(err, "failed to remove extension provider from the Istio mesh config", "name", current.Name)
+		return
+	}
+	authorinoInstance.Status.IstioExtensionProvider = nil
+}
+
+// removeIstioExtensionProvider removes the extension provider from the Istio mesh config, only if it points at the auth
+// service of the Authorino instance
+func (r *AuthorinoReconciler) removeIstioExtensionProvider(ctx context.Context, provider *api.IstioExtensionProviderStatus, authorinoInstance *api.Authorino) error {
+	registered := authorinoResources.NewIstioExtensionProvider(authorinoInstance.Name, authorinoInstance.Namespace, authGRPCServicePort(authorinoInstance))
+	registered["name"] = provider.Name
+	err := r.updateIstioMeshConfig(ctx, provider.MeshConfigMap, func(meshConfig string) (string, bool, error) {
+		return authorinoResources.RemoveIstioExtensionProvider(meshConfig, registered)
+	})
+	if errors.IsNotFound(err) {
+		// mesh config gone, nothing to remove
+		return nil
+	}
+	return err
+}
+
+// istioAuthorizationPolicy builds the AuthorizationPolicy of the Authorino instance, that uses its extension provider,
+// tagged to delete unless wanted in the spec
+func istioAuthorizationPolicy(authorino *api.Authorino) *unstructured.Unstructured {
+	istio := authorino.Spec.Integration.Istio
+	want := istio != nil && istio.AuthorizationPolicy != nil
+
+	var providerName string
+	var selector map[string]string
+	if want {
+		providerName = authorinoResources.IstioExtensionProviderName(authorino.Namespace, authorino.Name)
+		selector = istio.AuthorizationPolicy.Selector
+	}
+	policy := authorinoResources.NewIstioAuthorizationPolicy(authorino.Name, authorino.Namespace, providerName, selector, authorino.Labels)
+	if !want {
+		TagObjectToDelete(policy)
+	}
+	return policy
+}
+
+// istioMeshConfigMap returns the reference to the ConfigMap with the Istio mesh config set in the operator configuration
+func istioMeshConfigMap() api.NamespacedObjectReference {
+	if ref := config.Current().IstioMeshConfigMap; ref != nil {
+		return api.NamespacedObjectReference{Namespace: ref.Namespace, Name: ref.Name}
+	}
+	return api.NamespacedObjectReference{Namespace: DefaultIstioMeshConfigMapNamespace, Name: DefaultIstioMeshConfigMapName}
+}
+
+func (r *AuthorinoReconciler) updateIstioMeshConfig(ctx context.Context, ref api.NamespacedObjectReference, mutate func(meshConfig string) (string, bool, error)) error {
+	configMap := &k8score.ConfigMap{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, configMap); err != nil {
+		return err
+	}
+
+	meshConfig, changed, err := mutate(configMap.Data[authorinoResources.IstioMeshConfigKey])
+	if err != nil || !changed {
+		return err
+	}
+
+	if configMap.Data == nil {
+		configMap.Data = map[string]string{}
+	}
+	configMap.Data[authorinoResources.IstioMeshConfigKey] = meshConfig
+
+	// the mesh config is not owned by the operator, thus it is updated (relying on optimistic concurrency) instead of applied
+	return r.UpdateResource(ctx, configMap)
+}
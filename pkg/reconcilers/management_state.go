@@ -0,0 +1,145 @@
+package reconcilers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	k8sapps "k8s.io/api/apps/v1"
+	k8score "k8s.io/api/core/v1"
+	k8snetworking "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	k8srbac "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	k8smeta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/kuadrant/authorino-operator/api/v1beta1"
+	"github.com/kuadrant/authorino-operator/pkg/condition"
+	authorinoResources "github.com/kuadrant/authorino-operator/pkg/resources"
+)
+
+// ReconcileAuthorinoManagementState reports the management state of the Authorino instance in the Managed condition
+func (r *AuthorinoReconciler) ReconcileAuthorinoManagementState(_ context.Context, authorinoInstance *api.Authorino) error {
+	managed := api.Condition{
+		Type:   api.ConditionManaged,
+		Status: k8score.ConditionTrue,
+		Reason: api.ManagementStateManaged,
+	}
+	switch authorinoInstance.Spec.ManagementState {
+	case api.ManagementStateUnmanaged:
+		managed.Status = k8score.ConditionFalse
+		managed.Reason = api.ManagementStateUnmanaged
+		managed.Message = "reconciliation paused, only the status of the Authorino instance is reported"
+	case api.ManagementStateRemoved:
+		managed.Status = k8score.ConditionFalse
+		managed.Reason = api.ManagementStateRemoved
+		managed.Message = "resources of the Authorino instance removed"
+	}
+
+	if _, changed := condition.AddOrUpdateStatusConditions(authorinoInstance.Status.Conditions, managed); !changed {
+		return nil
+	}
+	return r.updateStatusConditions(authorinoInstance, managed)
+}
+
+// ReportAuthorinoDeploymentStatus reports the readiness of the Authorino Deployment, without reconciling it
+func (r *AuthorinoReconciler) ReportAuthorinoDeploymentStatus(ctx context.Context, authorinoInstance *api.Authorino) error {
+	logger, err := logr.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	deployment := &k8sapps.Deployment{}
+	err = r.Client.Get(ctx, client.ObjectKey{Namespace: authorinoInstance.Namespace, Name: authorinoInstance.Name}, deployment)
+
+	var ready api.Condition
+	switch {
+	case errors.IsNotFound(err):
+		ready = statusNotReady(statusDeploymentNotReady, "Authorino Deployment resource not found")
+	case err != nil:
+		return r.WrapErrorWithStatusUpdate(logger, authorinoInstance, r.SetStatusFailed(statusUnableToGetDeployment),
+			fmt.Errorf("failed to get %s Deployment resource, err: %v", authorinoInstance.Name, err))
+	default:
+		ready = deploymentReadyCondition(deployment)
+	}
+
+	if _, changed := condition.AddOrUpdateStatusConditions(authorinoInstance.Status.Conditions, ready); !changed {
+		return nil
+	}
+	return r.updateStatusConditions(authorinoInstance, ready)
+}
+
+// RemoveAuthorinoManagedResources tears down the namespaced resources controlled by the Authorino instance, the
+// bindings to the watched namespaces and the Istio extension provider, keeping the Authorino CR.
+// Cluster-scoped permissions are cleaned up by the controller, as on deletion of the CR.
+func (r *AuthorinoReconciler) RemoveAuthorinoManagedResources(ctx context.Context, authorinoInstance *api.Authorino) error {
+	logger, err := logr.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	lists := []client.ObjectList{
+		&k8sapps.DeploymentList{},
+		&k8score.ServiceList{},
+		&k8score.ServiceAccountList{},
+		&k8srbac.RoleList{},
+		&k8srbac.RoleBindingList{},
+		&k8snetworking.IngressList{},
+		&policyv1.PodDisruptionBudgetList{},
+	}
+	for _, gvk := range []schema.GroupVersionKind{
+		authorinoResources.RouteGVK,
+		authorinoResources.GRPCRouteGVK,
+		authorinoResources.HTTPRouteGVK,
+		authorinoResources.SecurityPolicyGVK,
+		authorinoResources.AuthorizationPolicyGVK,
+	} {
+		available, err := r.apiAvailable(gvk)
+		if err != nil {
+			return r.WrapErrorWithStatusUpdate(logger, authorinoInstance, r.SetStatusFailed(statusUnableToRemoveResources),
+				fmt.Errorf("failed to discover the %s API, err: %v", gvk.Kind, err))
+		}
+		if !available {
+			continue
+		}
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		lists = append(lists, list)
+	}
+
+	for _, list := range lists {
+		if err := r.Client.List(ctx, list, client.InNamespace(authorinoInstance.Namespace)); err != nil {
+			return r.WrapErrorWithStatusUpdate(logger, authorinoInstance, r.SetStatusFailed(statusUnableToRemoveResources),
+				fmt.Errorf("failed to list the resources of %s, err: %v", authorinoInstance.Name, err))
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			obj, ok := item.(client.Object)
+			if !ok || !k8smeta.IsControlledBy(obj, authorinoInstance) {
+				continue
+			}
+			if err := r.DeleteResource(ctx, obj); err != nil && !errors.IsNotFound(err) {
+				return r.WrapErrorWithStatusUpdate(logger, authorinoInstance, r.SetStatusFailed(statusUnableToRemoveResources),
+					fmt.Errorf("failed to remove %s %s, err: %v", obj.GetObjectKind().GroupVersionKind().Kind, obj.GetName(), err))
+			}
+		}
+	}
+
+	r.CleanupIstioExtensionProvider(ctx, authorinoInstance)
+
+	// nothing is watched anymore
+	authorinoInstance.Status.Shards = nil
+	authorinoInstance.Status.AuthConfigs = nil
+	authorinoInstance.Status.OIDCServerURL = ""
+	authorinoInstance.Status.Canary = nil
+	authorinoInstance.Status.Rollout = nil
+
+	return r.updateStatusConditions(authorinoInstance, statusNotReady(statusRemoved, "Authorino resources removed"))
+}
@@ -0,0 +1,205 @@
+package reconcilers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	k8score "k8s.io/api/core/v1"
+	k8snetworking "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/kuadrant/authorino-operator/api/v1beta1"
+	authorinoResources "github.com/kuadrant/authorino-operator/pkg/resources"
+)
+
+// ReconcileAuthorinoOIDCServerExposure exposes the OIDC server outside of the cluster with either an Ingress or an
+// OpenShift Route, and records the public URL of the OIDC server in the status of the Authorino CR.
+func (r *AuthorinoReconciler) ReconcileAuthorinoOIDCServerExposure(ctx context.Context, authorinoInstance *api.Authorino) error {
+	logger, err := logr.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	routeAPIAvailable, err := r.apiAvailable(authorinoResources.RouteGVK)
+	if err != nil {
+		return r.WrapErrorWithStatusUpdate(logger, authorinoInstance, r.SetStatusFailed(statusUnableToExposeOIDCServer),
+			fmt.Errorf("failed to discover the OpenShift Route API, err: %v", err))
+	}
+
+	kind := oidcServerExposeKind(authorinoInstance, routeAPIAvailable)
+	if kind == oidcServerExposeKindRoute && !routeAPIAvailable {
+		return r.WrapErrorWithStatusUpdate(logger, authorinoInstance, r.SetStatusFailed(statusUnableToExposeOIDCServer),
+			fmt.Errorf("failed to expose the OIDC server of %s, the OpenShift Route API is not available in the cluster", authorinoInstance.Name))
+	}
+	// the Ingress API has no standard way to route to a backend that serves TLS
+	if kind == oidcServerExposeKindIngress && oidcServerTLSEnabled(authorinoInstance) {
+		return r.WrapErrorWithStatusUpdate(logger, authorinoInstance, r.SetStatusFailed(statusUnableToExposeOIDCServer),
+			fmt.Errorf("failed to expose the OIDC server of %s with an Ingress, the OIDC server serves TLS, disable it or expose the OIDC server with a Route", authorinoInstance.Name))
+	}
+
+	expose := authorinoInstance.Spec.OIDCServer.Expose
+	var url string
+
+	// ingress
+	if _, err := r.reconcileOIDCServerExposure(ctx, &k8snetworking.Ingress{}, oidcServerIngress(authorinoInstance, kind), authorinoInstance); err != nil {
+		return err
+	}
+	if kind == oidcServerExposeKindIngress && expose.Host != "" {
+		scheme := "http"
+		if expose.TlsSecretRef != nil && expose.TlsSecretRef.Name != "" {
+			scheme = "https"
+		}
+		url = fmt.Sprintf("%s://%s", scheme, expose.Host)
+	}
+
+	// route
+	if routeAPIAvailable {
+		var destinationCACertificate string
+		if kind == oidcServerExposeKindRoute {
+			termination := oidcServerRouteTermination(authorinoInstance, expose)
+			// edge terminates TLS and sends plain HTTP to the OIDC server, the others send TLS
+			if (termination == "edge") == oidcServerTLSEnabled(authorinoInstance) {
+				return r.WrapErrorWithStatusUpdate(logger, authorinoInstance, r.SetStatusFailed(statusUnableToExposeOIDCServer),
+					fmt.Errorf("failed to expose the OIDC server of %s with a Route, %s termination does not match the TLS settings of the OIDC server", authorinoInstance.Name, termination))
+			}
+			if termination == "reencrypt" {
+				if destinationCACertificate, err = r.oidcServerCACertificate(ctx, authorinoInstance); err != nil {
+					return r.WrapErrorWithStatusUpdate(logger, authorinoInstance, r.SetStatusFailed(statusUnableToExposeOIDCServer),
+						fmt.Errorf("failed to get the CA certificate of the OIDC server of %s for the Route, err: %v", authorinoInstance.Name, err))
+				}
+			}
+		}
+		existing := &unstructured.Unstructured{}
+		existing.SetGroupVersionKind(authorinoResources.RouteGVK)
+		obj, err := r.reconcileOIDCServerExposure(ctx, existing, oidcServerRoute(authorinoInstance, kind, destinationCACertificate), authorinoInstance)
+		if err != nil {
+			return err
+		}
+		if kind == oidcServerExposeKindRoute {
+			if existingRoute, ok := obj.(*unstructured.Unstructured); ok {
+				if host := authorinoResources.RouteHost(existingRoute); host != "" {
+					url = fmt.Sprintf("https://%s", host)
+				}
+			}
+		}
+	}
+
+	if authorinoInstance.Status.OIDCServerURL != url {
+		authorinoInstance.Status.OIDCServerURL = url
+		return r.updateStatusConditions(authorinoInstance)
+	}
+
+	return nil
+}
+
+// oidcServerExposeKind returns the kind of the object that exposes the OIDC server of the Authorino instance, empty if
+// not exposed. It defaults to a Route where the OpenShift Route API is available, and to an Ingress otherwise.
+func oidcServerExposeKind(authorino *api.Authorino, routeAPIAvailable bool) string {
+	expose := authorino.Spec.OIDCServer.Expose
+	if expose == nil || !OIDCServerEnabled(authorino) {
+		return ""
+	}
+	if expose.Kind != "" {
+		return expose.Kind
+	}
+	if routeAPIAvailable {
+		return oidcServerExposeKindRoute
+	}
+	return oidcServerExposeKindIngress
+}
+
+// oidcServerIngress builds the Ingress of the OIDC server of the Authorino instance, tagged to delete unless the OIDC
+// server is exposed with an Ingress
+func oidcServerIngress(authorino *api.Authorino, kind string) *k8snetworking.Ingress {
+	port := DefaultOIDCServicePort
+	if p := authorino.Spec.OIDCServer.Port; p != nil {
+		port = *p
+	}
+	spec := &api.OIDCServerExpose{}
+	if kind == oidcServerExposeKindIngress {
+		spec = authorino.Spec.OIDCServer.Expose
+	}
+	var tlsSecretName string
+	if spec.TlsSecretRef != nil {
+		tlsSecretName = spec.TlsSecretRef.Name
+	}
+	ingress := authorinoResources.NewOIDCIngress(authorino.Name, authorino.Namespace, spec.Host, port, spec.IngressClassName, tlsSecretName, spec.Annotations, authorino.Labels)
+	if kind != oidcServerExposeKindIngress {
+		TagObjectToDelete(ingress)
+	}
+	return ingress
+}
+
+// oidcServerRoute builds the Route of the OIDC server of the Authorino instance, tagged to delete unless the OIDC
+// server is exposed with a Route
+func oidcServerRoute(authorino *api.Authorino, kind, destinationCACertificate string) *unstructured.Unstructured {
+	spec := &api.OIDCServerExpose{}
+	if kind == oidcServerExposeKindRoute {
+		spec = authorino.Spec.OIDCServer.Expose
+	}
+	route := authorinoResources.NewOIDCRoute(authorino.Name, authorino.Namespace, spec.Host, oidcServerRouteTermination(authorino, spec), destinationCACertificate, spec.Annotations, authorino.Labels)
+	if kind != oidcServerExposeKindRoute {
+		TagObjectToDelete(route)
+	}
+	return route
+}
+
+// oidcServerRouteTermination returns the TLS termination of the Route of the OIDC server, defaulting to edge when the
+// OIDC server serves plain HTTP, and to passthrough otherwise
+func oidcServerRouteTermination(authorino *api.Authorino, expose *api.OIDCServerExpose) string {
+	if expose.Termination != "" {
+		return expose.Termination
+	}
+	if oidcServerTLSEnabled(authorino) {
+		return "passthrough"
+	}
+	return "edge"
+}
+
+// oidcServerTLSEnabled tells whether the OIDC server of the Authorino instance serves TLS
+func oidcServerTLSEnabled(authorino *api.Authorino) bool {
+	enabled := authorino.Spec.OIDCServer.Tls.Enabled
+	return enabled == nil || *enabled
+}
+
+// oidcServerCACertificate returns the CA certificate that a Route reencrypting TLS to the OIDC server of the Authorino
+// instance validates the certificate of the OIDC server with: the ca.crt of the Secret with the certificate, as set by
+// cert-manager, or else the certificate itself (self-signed)
+func (r *AuthorinoReconciler) oidcServerCACertificate(ctx context.Context, authorino *api.Authorino) (string, error) {
+	secretRef := authorino.Spec.OIDCServer.Tls.CertSecret
+	if secretRef == nil || secretRef.Name == "" {
+		return "", fmt.Errorf("oidc secret with tls cert not provided")
+	}
+	secret := &k8score.Secret{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Namespace: authorino.Namespace, Name: secretRef.Name}, secret); err != nil {
+		return "", err
+	}
+	for _, key := range []string{"ca.crt", k8score.TLSCertKey} {
+		if ca := secret.Data[key]; len(ca) > 0 {
+			return string(ca), nil
+		}
+	}
+	return "", fmt.Errorf("secret %s has no ca.crt nor %s", secretRef.Name, k8score.TLSCertKey)
+}
+
+func (r *AuthorinoReconciler) reconcileOIDCServerExposure(ctx context.Context, obj, desired client.Object, authorino *api.Authorino) (client.Object, error) {
+	logger, err := logr.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ctrl.SetControllerReference(authorino, desired, r.Scheme); err != nil {
+		return nil, err
+	}
+
+	crud, current, err := r.reconcileResource(ctx, obj, desired)
+	if err != nil {
+		return nil, r.WrapErrorWithStatusUpdate(logger, authorino, r.SetStatusFailed(statusUnableToExposeOIDCServer),
+			fmt.Errorf("failed to %s %s for the OIDC server, err: %v", crud, desired.GetObjectKind().GroupVersionKind().Kind, err))
+	}
+
+	return current, nil
+}
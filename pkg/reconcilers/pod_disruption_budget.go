@@ -0,0 +1,42 @@
+package reconcilers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	policyv1 "k8s.io/api/policy/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	api "github.com/kuadrant/authorino-operator/api/v1beta1"
+	authorinoResources "github.com/kuadrant/authorino-operator/pkg/resources"
+)
+
+// ReconcileAuthorinoPodDisruptionBudget reconciles the PodDisruptionBudget of a highly available Authorino instance
+func (r *AuthorinoReconciler) ReconcileAuthorinoPodDisruptionBudget(ctx context.Context, authorinoInstance *api.Authorino) error {
+	logger, err := logr.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	pdb := AuthorinoPodDisruptionBudget(authorinoInstance)
+	if err := ctrl.SetControllerReference(authorinoInstance, pdb, r.Scheme); err != nil {
+		return err
+	}
+
+	if _, _, err := r.reconcileResource(ctx, &policyv1.PodDisruptionBudget{}, pdb); err != nil {
+		return r.WrapErrorWithStatusUpdate(logger, authorinoInstance, r.SetStatusFailed(statusUnableToReconcilePodDisruptionBudget),
+			fmt.Errorf("failed to reconcile %s PodDisruptionBudget, err: %v", pdb.Name, err))
+	}
+	return nil
+}
+
+// AuthorinoPodDisruptionBudget builds the PodDisruptionBudget of the Authorino instance, which covers the pods of the
+// canary as well. Tagged to delete unless the instance is highly available.
+func AuthorinoPodDisruptionBudget(authorinoInstance *api.Authorino) *policyv1.PodDisruptionBudget {
+	pdb := authorinoResources.GetPodDisruptionBudget(authorinoInstance.Name, authorinoInstance.Namespace, authorinoInstance.Labels)
+	if !authorinoInstance.Spec.HighAvailability {
+		TagObjectToDelete(pdb)
+	}
+	return pdb
+}
@@ -0,0 +1,151 @@
+package reconcilers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	k8srbac "k8s.io/api/rbac/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	api "github.com/kuadrant/authorino-operator/api/v1beta1"
+	"github.com/kuadrant/authorino-operator/pkg/config"
+	authorinoResources "github.com/kuadrant/authorino-operator/pkg/resources"
+)
+
+// rbacRules returns the extra permissions of the Authorino instance, none unless the operator configuration allows all
+// of them
+func rbacRules(authorino *api.Authorino) []k8srbac.PolicyRule {
+	if authorino.Spec.RBAC == nil {
+		return nil
+	}
+	for _, rule := range authorino.Spec.RBAC.Rules {
+		if !config.Current().RuleAllowed(rule) {
+			return nil
+		}
+	}
+	return authorino.Spec.RBAC.Rules
+}
+
+// rbacNotAllowed returns an error when the Authorino instance picks ClusterRoles or extra permissions in spec.rbac that
+// the operator configuration does not allow. These are not granted: the ClusterRoles of the operator configuration are
+// bound instead, and none of the extra permissions.
+func rbacNotAllowed(authorino *api.Authorino) error {
+	if authorino.Spec.RBAC == nil {
+		return nil
+	}
+	if name := authorino.Spec.RBAC.ManagerClusterRole; name != "" && name != ManagerClusterRoleName(authorino) {
+		return fmt.Errorf("ClusterRole %s in spec.rbac.managerClusterRole not allowed by the operator configuration", name)
+	}
+	if name := authorino.Spec.RBAC.K8sAuthClusterRole; name != "" && name != K8sAuthClusterRoleName(authorino) {
+		return fmt.Errorf("ClusterRole %s in spec.rbac.k8sAuthClusterRole not allowed by the operator configuration", name)
+	}
+	for i, rule := range authorino.Spec.RBAC.Rules {
+		if !config.Current().RuleAllowed(rule) {
+			return fmt.Errorf("spec.rbac.rules[%d] not allowed by the operator configuration", i)
+		}
+	}
+	return nil
+}
+
+// kubernetesAuth returns whether the Authorino instance is granted the permissions to create TokenReviews and
+// SubjectAccessReviews: Enabled (default), Disabled or Auto
+func kubernetesAuth(authorino *api.Authorino) string {
+	if authorino.Spec.RBAC == nil || authorino.Spec.RBAC.KubernetesAuth == "" {
+		return api.KubernetesAuthEnabled
+	}
+	return authorino.Spec.RBAC.KubernetesAuth
+}
+
+// kubernetesAuthUsed tells whether any AuthConfig in the watch scope of the Authorino instance authenticates with
+// Kubernetes TokenReviews or authorizes with Kubernetes SubjectAccessReviews.
+// None does when the AuthConfig API is not available in the cluster.
+func (r *AuthorinoReconciler) kubernetesAuthUsed(ctx context.Context, authorinoInstance *api.Authorino) (bool, error) {
+	available, err := r.apiAvailable(authorinoResources.AuthConfigGVK)
+	if err != nil || !available {
+		return false, err
+	}
+
+	authConfigs, err := r.listWatchedAuthConfigs(ctx, authorinoInstance, true)
+	if err != nil {
+		return false, err
+	}
+	for i := range authConfigs {
+		if authorinoResources.AuthConfigUsesKubernetesAuth(&authConfigs[i]) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// reconcileRBACRules grants the Authorino instance the extra permissions of spec.rbac.rules within its watch scope:
+// a Role bound in the namespace of the instance, when namespaced, or else a ClusterRole bound cluster-wide.
+// The roles and bindings not needed for the rules and scope of the instance are deleted.
+func (r *AuthorinoReconciler) reconcileRBACRules(ctx context.Context, authorinoInstance *api.Authorino) error {
+	logger, err := logr.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	role := rulesRole(authorinoInstance)
+	if err := ctrl.SetControllerReference(authorinoInstance, role, r.Scheme); err != nil {
+		return err
+	}
+	if _, _, err := r.reconcileResource(ctx, &k8srbac.Role{}, role); err != nil {
+		return r.WrapErrorWithStatusUpdate(logger, authorinoInstance, r.SetStatusFailed(statusUnableToReconcileRBACRules),
+			fmt.Errorf("failed to reconcile %s role, err: %v", role.Name, err))
+	}
+	if err := r.reconcileRoleBinding(ctx, rulesRoleBinding(authorinoInstance), authorinoInstance); err != nil {
+		return err
+	}
+
+	clusterRole := rulesClusterRole(authorinoInstance)
+	if _, _, err := r.reconcileResource(ctx, &k8srbac.ClusterRole{}, clusterRole); err != nil {
+		return r.WrapErrorWithStatusUpdate(logger, authorinoInstance, r.SetStatusFailed(statusUnableToReconcileRBACRules),
+			fmt.Errorf("failed to reconcile %s cluster role, err: %v", clusterRole.Name, err))
+	}
+	return r.reconcileClusterRoleBinding(ctx, rulesClusterRoleBinding(authorinoInstance), authorinoInstance)
+}
+
+// rulesRole builds the Role with the extra permissions of the Authorino instance, tagged to delete unless the instance
+// is namespaced and has extra permissions
+func rulesRole(authorinoInstance *api.Authorino) *k8srbac.Role {
+	role := authorinoResources.GetAuthorinoRole(authorinoInstance.Namespace, authorinoInstance.Name, AuthorinoRBACRulesRoleName, rbacRules(authorinoInstance), authorinoInstance.Labels)
+	if len(rbacRules(authorinoInstance)) == 0 || WatchScope(authorinoInstance) != config.ScopeNamespaced {
+		TagObjectToDelete(role)
+	}
+	return role
+}
+
+// rulesRoleBinding builds the RoleBinding for the Role with the extra permissions of the Authorino instance
+func rulesRoleBinding(authorinoInstance *api.Authorino) *k8srbac.RoleBinding {
+	role := rulesRole(authorinoInstance)
+	sa := authorinoResources.GetAuthorinoServiceAccount(authorinoInstance.Namespace, authorinoInstance.Name, authorinoInstance.Labels)
+	binding := authorinoResources.GetAuthorinoRoleBinding(authorinoInstance.Namespace, authorinoInstance.Name, AuthorinoRBACRulesRoleName, "Role", role.Name, sa, authorinoInstance.Labels)
+	if IsObjectTaggedToDelete(role) {
+		TagObjectToDelete(binding)
+	}
+	return binding
+}
+
+// rulesClusterRole builds the ClusterRole with the extra permissions of the Authorino instance, tagged to delete when
+// the instance is namespaced or has no extra permissions
+func rulesClusterRole(authorinoInstance *api.Authorino) *k8srbac.ClusterRole {
+	clusterRole := authorinoResources.GetAuthorinoClusterRole(authorinoInstance.Namespace, authorinoInstance.Name, AuthorinoRBACRulesRoleName, rbacRules(authorinoInstance), authorinoInstance.Labels)
+	if len(rbacRules(authorinoInstance)) == 0 || WatchScope(authorinoInstance) == config.ScopeNamespaced {
+		TagObjectToDelete(clusterRole)
+	}
+	return clusterRole
+}
+
+// rulesClusterRoleBinding builds the ClusterRoleBinding for the ClusterRole with the extra permissions of the
+// Authorino instance, tagged to delete unless the instance is cluster-wide and has extra permissions
+func rulesClusterRoleBinding(authorinoInstance *api.Authorino) *k8srbac.ClusterRoleBinding {
+	clusterRole := rulesClusterRole(authorinoInstance)
+	sa := authorinoResources.GetAuthorinoServiceAccount(authorinoInstance.Namespace, authorinoInstance.Name, authorinoInstance.Labels)
+	binding := authorinoResources.GetAuthorinoClusterRoleBinding(authorinoInstance.Namespace, authorinoInstance.Name, AuthorinoRBACRulesRoleName, clusterRole.Name, sa, authorinoInstance.Labels)
+	if IsObjectTaggedToDelete(clusterRole) || !authorinoInstance.Spec.ClusterWide {
+		TagObjectToDelete(binding)
+	}
+	return binding
+}
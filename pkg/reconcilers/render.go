@@ -0,0 +1,64 @@
+package reconcilers
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/kuadrant/authorino-operator/api/v1beta1"
+	authorinoResources "github.com/kuadrant/authorino-operator/pkg/resources"
+)
+
+// RenderAuthorino builds the resources the operator reconciles for the Authorino instance (Services, OIDC server
+// Ingress or Route, Gateway API and Istio integration objects, ServiceAccount, RBAC, PodDisruptionBudget and
+// Deployments) without a cluster, in the order they are reconciled. Resources tagged to delete are omitted.
+// Whatever depends on the state of the cluster is rendered from the Authorino CR alone, e.g. the namespaces watched
+// with a namespace selector are the ones in the status of the CR, the OIDC server is exposed by default with a Route
+// only if routeAPIAvailable, a reencrypt Route has no destination CA certificate, the Istio mesh config is not
+// rendered, and owner references are not set.
+// Nothing is rendered for an instance whose resources are removed.
+func RenderAuthorino(authorino *api.Authorino, routeAPIAvailable bool) ([]client.Object, error) {
+	if authorino.Spec.ManagementState == api.ManagementStateRemoved {
+		return nil, nil
+	}
+
+	if AuthorinoImage(authorino) == "" {
+		return nil, fmt.Errorf("no Authorino image for %s, set spec.image or the %s env var", authorino.Name, RelatedImageAuthorino)
+	}
+
+	integration := authorino.Spec.Integration
+	if (integration.GatewayAPI != nil || integration.Istio != nil) && !AuthServiceEnabled(authorino) {
+		return nil, fmt.Errorf("cannot integrate %s with the Gateway API or Istio, the auth Service is disabled", authorino.Name)
+	}
+	kind := oidcServerExposeKind(authorino, routeAPIAvailable)
+	if kind == oidcServerExposeKindIngress && oidcServerTLSEnabled(authorino) {
+		return nil, fmt.Errorf("cannot expose the OIDC server of %s with an Ingress, the OIDC server serves TLS", authorino.Name)
+	}
+
+	var objs []client.Object
+
+	for _, service := range AuthorinoServices(authorino) {
+		objs = append(objs, service)
+	}
+
+	objs = append(objs, oidcServerIngress(authorino, kind), oidcServerRoute(authorino, kind, ""))
+	for _, gvk := range gatewayAPIKinds {
+		objs = append(objs, gatewayAPIObject(authorino, gvk))
+	}
+	objs = append(objs, istioAuthorizationPolicy(authorino))
+
+	sa := authorinoResources.GetAuthorinoServiceAccount(authorino.Namespace, authorino.Name, authorino.Labels)
+	objs = append(objs, sa, managerClusterRoleBinding(authorino), managerRoleBinding(authorino))
+	objs = append(objs, rulesRole(authorino), rulesRoleBinding(authorino), rulesClusterRole(authorino), rulesClusterRoleBinding(authorino))
+	objs = append(objs, k8sAuthClusterRoleBinding(authorino), leaderElectionRole(authorino), leaderElectionRoleBinding(authorino))
+
+	objs = append(objs, AuthorinoPodDisruptionBudget(authorino), AuthorinoCanaryDeployment(authorino), AuthorinoDeployment(authorino))
+
+	var rendered []client.Object
+	for _, obj := range objs {
+		if !IsObjectTaggedToDelete(obj) {
+			rendered = append(rendered, obj)
+		}
+	}
+	return rendered, nil
+}
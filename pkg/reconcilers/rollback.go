@@ -0,0 +1,193 @@
+package reconcilers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	k8sapps "k8s.io/api/apps/v1"
+	k8score "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	k8smeta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/kuadrant/authorino-operator/api/v1beta1"
+)
+
+// DeploymentRevisionAnnotation tells the revision of the pod template applied to the Authorino Deployment, i.e. the
+// one built from the spec of the Authorino instance or, when rolled back, the last known-good one
+const DeploymentRevisionAnnotation = "operator.authorino.kuadrant.io/revision"
+
+// reconcileRollback tracks the revisions of the desired Authorino Deployment out of the live one, when automatic
+// rollback is enabled. The ReplicaSet of a revision whose Deployment is rolled out is remembered as the last known-good
+// one; a revision whose rollout fails, or that is not rolled out within the timeout, is recorded as failed, and the
+// desired Deployment is rolled back to the pod template of the last known-good ReplicaSet, as `kubectl rollout undo`
+// does, for as long as the spec does not change.
+func (r *AuthorinoReconciler) reconcileRollback(ctx context.Context, authorinoInstance *api.Authorino, desired *k8sapps.Deployment) error {
+	if !autoRollbackEnabled(authorinoInstance) {
+		if authorinoInstance.Status.Rollout == nil {
+			return nil
+		}
+		authorinoInstance.Status.Rollout = nil
+		return r.updateStatusConditions(authorinoInstance)
+	}
+
+	logger, err := logr.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	revision := podTemplateRevision(&desired.Spec.Template)
+	status := authorinoInstance.Status.Rollout.DeepCopy()
+	if status == nil {
+		status = &api.RolloutStatus{}
+	}
+	if status.Revision != revision {
+		status.Revision = revision
+		status.RevisionTime = k8smeta.Now()
+	}
+
+	deployment := &k8sapps.Deployment{}
+	err = r.Client.Get(ctx, client.ObjectKeyFromObject(desired), deployment)
+	if err != nil && !errors.IsNotFound(err) {
+		return r.WrapErrorWithStatusUpdate(logger, authorinoInstance, r.SetStatusFailed(statusUnableToGetDeployment),
+			fmt.Errorf("failed to get %s Deployment resource, err: %v", authorinoInstance.Name, err))
+	}
+
+	// only the live Deployment at the desired revision tells about it
+	if err == nil && deployment.Annotations[DeploymentRevisionAnnotation] == revision {
+		timeout := time.Duration(*authorinoInstance.Spec.Rollout.RollbackAfterSeconds) * time.Second
+		failed, message := DeploymentRolloutFailed(deployment)
+		switch {
+		case DeploymentRolledOut(deployment) && status.LastKnownGoodRevision != revision:
+			replicaSet, err := r.currentReplicaSet(ctx, deployment)
+			if err != nil {
+				return r.WrapErrorWithStatusUpdate(logger, authorinoInstance, r.SetStatusFailed(statusUnableToGetDeployment),
+					fmt.Errorf("failed to get the ReplicaSet of %s Deployment resource, err: %v", authorinoInstance.Name, err))
+			}
+			// not yet in the cache otherwise, and reconciled again once it is
+			if replicaSet != nil {
+				status.LastKnownGoodRevision = revision
+				status.LastKnownGoodReplicaSet = replicaSet.Name
+			}
+		case status.LastKnownGoodReplicaSet == "" || status.LastKnownGoodRevision == revision:
+			// nothing to roll back to
+		case failed:
+			status.FailedRevision = revision
+			status.FailureReason = fmt.Sprintf("rollout failed: %s", message)
+		case time.Since(status.RevisionTime.Time) >= timeout:
+			status.FailedRevision = revision
+			status.FailureReason = fmt.Sprintf("not available within %s", timeout)
+		}
+	}
+
+	annotations := desired.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[DeploymentRevisionAnnotation] = revision
+	if rolledBack(status) {
+		replicaSet := &k8sapps.ReplicaSet{}
+		err := r.Client.Get(ctx, client.ObjectKey{Namespace: desired.Namespace, Name: status.LastKnownGoodReplicaSet}, replicaSet)
+		switch {
+		case errors.IsNotFound(err):
+			// out of the revision history of the Deployment
+			logger.Info("last known-good ReplicaSet not found, cannot roll back", "name", status.LastKnownGoodReplicaSet)
+			status.LastKnownGoodRevision = ""
+			status.LastKnownGoodReplicaSet = ""
+		case err != nil:
+			return r.WrapErrorWithStatusUpdate(logger, authorinoInstance, r.SetStatusFailed(statusUnableToGetDeployment),
+				fmt.Errorf("failed to get the last known-good ReplicaSet of %s Deployment resource, err: %v", authorinoInstance.Name, err))
+		default:
+			desired.Spec.Template = *replicaSet.Spec.Template.DeepCopy()
+			delete(desired.Spec.Template.Labels, k8sapps.DefaultDeploymentUniqueLabelKey)
+			annotations[DeploymentRevisionAnnotation] = status.LastKnownGoodRevision
+		}
+	}
+	desired.SetAnnotations(annotations)
+
+	if reflect.DeepEqual(authorinoInstance.Status.Rollout, status) {
+		return nil
+	}
+	newlyRolledBack := rolledBack(status) && (authorinoInstance.Status.Rollout == nil || authorinoInstance.Status.Rollout.FailedRevision != status.FailedRevision)
+	authorinoInstance.Status.Rollout = status
+	if err := r.updateStatusConditions(authorinoInstance); err != nil {
+		return err
+	}
+	if newlyRolledBack && r.Recorder != nil {
+		r.Recorder.Eventf(authorinoInstance, nil, k8score.EventTypeWarning, statusRolledBack, "RollBack",
+			"Authorino Deployment rolled back to revision %s, revision %s %s", status.LastKnownGoodRevision, status.FailedRevision, status.FailureReason)
+	}
+	return nil
+}
+
+// deploymentRolledBack tells whether the Authorino Deployment is rolled back to the last known-good revision, because
+// the revision desired failed, returning why
+func deploymentRolledBack(authorino *api.Authorino) (bool, string) {
+	status := authorino.Status.Rollout
+	if !autoRollbackEnabled(authorino) || !rolledBack(status) {
+		return false, ""
+	}
+	return true, fmt.Sprintf("Authorino Deployment rolled back to revision %s, revision %s %s", status.LastKnownGoodRevision, status.FailedRevision, status.FailureReason)
+}
+
+// RolloutRequeueAfter returns when the revision of the Authorino Deployment desired is due to be rolled back for not
+// becoming available in time, so the instance is reconciled again even if nothing else changes.
+// Zero means there is no revision to roll back.
+func RolloutRequeueAfter(authorino *api.Authorino) time.Duration {
+	status := authorino.Status.Rollout
+	if !autoRollbackEnabled(authorino) || status == nil || status.LastKnownGoodReplicaSet == "" ||
+		status.LastKnownGoodRevision == status.Revision || status.FailedRevision == status.Revision {
+		return 0
+	}
+
+	timeout := time.Duration(*authorino.Spec.Rollout.RollbackAfterSeconds) * time.Second
+	if after := time.Until(status.RevisionTime.Add(timeout)); after > time.Second {
+		return after
+	}
+	return time.Second
+}
+
+func autoRollbackEnabled(authorino *api.Authorino) bool {
+	return authorino.Spec.Rollout != nil && authorino.Spec.Rollout.RollbackAfterSeconds != nil
+}
+
+func rolledBack(status *api.RolloutStatus) bool {
+	return status != nil && status.FailedRevision == status.Revision && status.LastKnownGoodReplicaSet != ""
+}
+
+// currentReplicaSet returns the ReplicaSet of the Deployment with the pod template of the Deployment, as the Deployment
+// controller tells the ReplicaSet of the revision of the Deployment. Nil if not found.
+func (r *AuthorinoReconciler) currentReplicaSet(ctx context.Context, deployment *k8sapps.Deployment) (*k8sapps.ReplicaSet, error) {
+	replicaSetList := &k8sapps.ReplicaSetList{}
+	if err := r.Client.List(ctx, replicaSetList, client.InNamespace(deployment.Namespace), client.MatchingLabels(deployment.Spec.Selector.MatchLabels)); err != nil {
+		return nil, err
+	}
+	for i := range replicaSetList.Items {
+		replicaSet := &replicaSetList.Items[i]
+		if owner := k8smeta.GetControllerOf(replicaSet); owner == nil || owner.UID != deployment.UID {
+			continue
+		}
+		template := replicaSet.Spec.Template.DeepCopy()
+		delete(template.Labels, k8sapps.DefaultDeploymentUniqueLabelKey)
+		if equality.Semantic.DeepEqual(*template, deployment.Spec.Template) {
+			return replicaSet, nil
+		}
+	}
+	return nil, nil
+}
+
+// podTemplateRevision hashes the pod template, as the Deployment controller does for the ReplicaSets
+func podTemplateRevision(template *k8score.PodTemplateSpec) string {
+	data, _ := json.Marshal(template)
+	hash := fnv.New32a()
+	_, _ = hash.Write(data)
+	return rand.SafeEncodeString(strconv.FormatUint(uint64(hash.Sum32()), 10))
+}
@@ -0,0 +1,197 @@
+package reconcilers
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	k8score "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/kuadrant/authorino-operator/api/v1beta1"
+	"github.com/kuadrant/authorino-operator/pkg/condition"
+)
+
+// ReconcileAuthorinoScopeOverlap detects other Authorino instances whose watch scope (watched namespaces and AuthConfig
+// label selectors) intersects with the one of the Authorino instance, i.e. instances that would reconcile the same
+// AuthConfigs and fight over their status. Overlaps are reported in the Conflict condition and with a Warning event.
+func (r *AuthorinoReconciler) ReconcileAuthorinoScopeOverlap(ctx context.Context, authorinoInstance *api.Authorino) error {
+	logger, err := logr.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	authorinoList := &api.AuthorinoList{}
+	if err := r.Client.List(ctx, authorinoList); err != nil {
+		return r.WrapErrorWithStatusUpdate(logger, authorinoInstance, r.SetStatusFailed(statusUnableToDetectScopeOverlap),
+			fmt.Errorf("failed to list Authorino instances, err: %v", err))
+	}
+
+	var conflicts []string
+	for i := range authorinoList.Items {
+		other := &authorinoList.Items[i]
+		if other.Namespace == authorinoInstance.Namespace && other.Name == authorinoInstance.Name {
+			continue
+		}
+		// instances being deleted or whose resources are removed do not watch any AuthConfig
+		if other.DeletionTimestamp != nil || other.Spec.ManagementState == api.ManagementStateRemoved {
+			continue
+		}
+		overlap, err := watchScopesIntersect(authorinoInstance, other)
+		if err != nil {
+			logger.V(1).Info("skipping overlap detection", "other", client.ObjectKeyFromObject(other), "reason", err.Error())
+			continue
+		}
+		if overlap {
+			conflicts = append(conflicts, client.ObjectKeyFromObject(other).String())
+		}
+	}
+	slices.Sort(conflicts)
+
+	conflict := api.Condition{
+		Type:   api.ConditionConflict,
+		Status: k8score.ConditionFalse,
+		Reason: statusNoScopeOverlap,
+	}
+	if len(conflicts) > 0 {
+		conflict.Status = k8score.ConditionTrue
+		conflict.Reason = statusScopeOverlap
+		conflict.Message = fmt.Sprintf("watch scope overlaps with: %s", strings.Join(conflicts, ", "))
+	}
+
+	if _, changed := condition.AddOrUpdateStatusConditions(authorinoInstance.Status.Conditions, conflict); !changed {
+		return nil
+	}
+
+	if conflict.Status == k8score.ConditionTrue && r.Recorder != nil {
+		r.Recorder.Eventf(authorinoInstance, nil, k8score.EventTypeWarning, statusScopeOverlap, "DetectScopeOverlap",
+			"Authorino instances reconcile the same AuthConfigs and fight over their status, %s", conflict.Message)
+	}
+
+	return r.updateStatusConditions(authorinoInstance, conflict)
+}
+
+// watchScopesIntersect tells whether two Authorino instances may watch the same AuthConfigs
+func watchScopesIntersect(a, b *api.Authorino) (bool, error) {
+	namespacesA, namespacesB := WatchedNamespaces(a), WatchedNamespaces(b)
+	if namespacesA != nil && namespacesB != nil && !sets.New(namespacesA...).HasAny(namespacesB...) {
+		return false, nil
+	}
+
+	return labelSelectorsIntersect(AuthConfigLabelSelectors(a), AuthConfigLabelSelectors(b))
+}
+
+// labelSelectorsIntersect tells whether there is a set of labels that matches both label selectors, i.e. whether the
+// conjunction of the requirements of both selectors is satisfiable
+func labelSelectorsIntersect(a, b string) (bool, error) {
+	var requirements []labels.Requirement
+	for _, s := range []string{a, b} {
+		selector, err := labels.Parse(s)
+		if err != nil {
+			return false, err
+		}
+		reqs, _ := selector.Requirements()
+		requirements = append(requirements, reqs...)
+	}
+
+	byKey := map[string][]labels.Requirement{}
+	for _, req := range requirements {
+		byKey[req.Key()] = append(byKey[req.Key()], req)
+	}
+
+	for _, reqs := range byKey {
+		if !labelRequirementsSatisfiable(reqs) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// labelRequirementsSatisfiable tells whether there is a value (or the absence of the label) that satisfies all the
+// requirements of a single label key
+func labelRequirementsSatisfiable(reqs []labels.Requirement) bool {
+	var allowed sets.Set[string] // nil means any value
+	excluded := sets.New[string]()
+	mustExist, mustNotExist := false, false
+	lower, upper := int64(math.MinInt64), int64(math.MaxInt64) // exclusive bounds
+
+	for _, req := range reqs {
+		values := req.ValuesUnsorted()
+		switch req.Operator() {
+		case selection.Equals, selection.DoubleEquals, selection.In:
+			mustExist = true
+			if allowed == nil {
+				allowed = sets.New(values...)
+			} else {
+				allowed = allowed.Intersection(sets.New(values...))
+			}
+		case selection.NotEquals, selection.NotIn:
+			excluded.Insert(values...)
+		case selection.Exists:
+			mustExist = true
+		case selection.DoesNotExist:
+			mustNotExist = true
+		case selection.GreaterThan, selection.LessThan:
+			mustExist = true
+			for _, v := range values {
+				n, err := strconv.ParseInt(v, 10, 64)
+				if err != nil {
+					continue
+				}
+				if req.Operator() == selection.GreaterThan {
+					lower = max(lower, n)
+				} else {
+					upper = min(upper, n)
+				}
+			}
+		}
+	}
+
+	if !mustExist {
+		return true // the absence of the label satisfies all the requirements
+	}
+	if mustNotExist {
+		return false
+	}
+
+	inBounds := func(v string) bool {
+		if lower == math.MinInt64 && upper == math.MaxInt64 {
+			return true
+		}
+		n, err := strconv.ParseInt(v, 10, 64)
+		return err == nil && n > lower && n < upper
+	}
+
+	if allowed != nil {
+		for v := range allowed {
+			if !excluded.Has(v) && inBounds(v) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if lower == math.MinInt64 || upper == math.MaxInt64 {
+		return true // infinitely many values not excluded
+	}
+	if upper <= lower {
+		return false
+	}
+	// number of integers strictly between the bounds (computed unsigned, as the difference may overflow int64)
+	candidates := uint64(upper) - uint64(lower) - 1
+	var excludedInBounds uint64
+	for v := range excluded {
+		if inBounds(v) {
+			excludedInBounds++
+		}
+	}
+	return candidates > excludedInBounds
+}
@@ -0,0 +1,166 @@
+package reconcilers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/kuadrant/authorino-operator/api/v1beta1"
+	authorinoResources "github.com/kuadrant/authorino-operator/pkg/resources"
+)
+
+// AuthConfigLabelSelectors returns the AuthConfig label selectors of the Authorino instance, narrowed to the shard of
+// the instance when sharding is enabled
+func AuthConfigLabelSelectors(authorino *api.Authorino) string {
+	selectors := authorino.Spec.AuthConfigLabelSelectors
+	sharding := authorino.Spec.Sharding
+	if sharding == nil {
+		return selectors
+	}
+
+	shardSelector := fmt.Sprintf("%s=%d", authorinoResources.ShardLabel(sharding.Group), sharding.Index)
+	if selectors == "" {
+		return shardSelector
+	}
+	return selectors + "," + shardSelector
+}
+
+// ReconcileAuthorinoSharding assigns each AuthConfig watched by the Authorino instance to a shard of its sharding group,
+// by labeling the AuthConfig, and records the number of AuthConfigs per shard in the status of the Authorino CR.
+// Every instance of the group computes the same assignment, so any of them can label a new AuthConfig.
+// Shard labels are not removed when sharding is disabled, as other instances of the group may still rely on them.
+func (r *AuthorinoReconciler) ReconcileAuthorinoSharding(ctx context.Context, authorinoInstance *api.Authorino) error {
+	logger, err := logr.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	sharding := authorinoInstance.Spec.Sharding
+	if sharding == nil {
+		if authorinoInstance.Status.Shards != nil {
+			authorinoInstance.Status.Shards = nil
+			return r.updateStatusConditions(authorinoInstance)
+		}
+		return nil
+	}
+
+	available, err := r.apiAvailable(authorinoResources.AuthConfigGVK)
+	if err != nil {
+		return r.WrapErrorWithStatusUpdate(logger, authorinoInstance, r.SetStatusFailed(statusUnableToShardAuthConfigs),
+			fmt.Errorf("failed to discover the AuthConfig API, err: %v", err))
+	}
+	if !available {
+		return r.WrapErrorWithStatusUpdate(logger, authorinoInstance, r.SetStatusFailed(statusUnableToShardAuthConfigs),
+			fmt.Errorf("failed to shard the AuthConfigs of %s, the AuthConfig API is not available in the cluster", authorinoInstance.Name))
+	}
+
+	authConfigs, err := r.listWatchedAuthConfigs(ctx, authorinoInstance, false)
+	if err != nil {
+		return r.WrapErrorWithStatusUpdate(logger, authorinoInstance, r.SetStatusFailed(statusUnableToShardAuthConfigs),
+			fmt.Errorf("failed to list the AuthConfigs of %s, err: %v", authorinoInstance.Name, err))
+	}
+
+	shardLabel := authorinoResources.ShardLabel(sharding.Group)
+	byNamespace := sharding.Strategy == shardingStrategyNamespace
+	counts := make([]int32, sharding.Shards)
+
+	for i := range authConfigs {
+		authConfig := &authConfigs[i]
+		shard := authorinoResources.AuthConfigShard(authConfig.GetNamespace(), authConfig.GetName(), sharding.Shards, byNamespace)
+		counts[shard]++
+
+		value := strconv.Itoa(int(shard))
+		if authConfig.GetLabels()[shardLabel] == value {
+			continue
+		}
+		// merge patch of the shard label only, to not interfere with the other fields of the AuthConfig
+		patch := fmt.Appendf(nil, `{"metadata":{"labels":{%q:%q}}}`, shardLabel, value)
+		logger.V(1).Info("assign AuthConfig to shard", "authconfig", client.ObjectKeyFromObject(authConfig), "shard", value)
+		if err := r.Client.Patch(ctx, authConfig, client.RawPatch(types.MergePatchType, patch)); err != nil {
+			return r.WrapErrorWithStatusUpdate(logger, authorinoInstance, r.SetStatusFailed(statusUnableToShardAuthConfigs),
+				fmt.Errorf("failed to assign AuthConfig %s to shard %s, err: %v", client.ObjectKeyFromObject(authConfig), value, err))
+		}
+	}
+
+	shards := make([]api.ShardStatus, sharding.Shards)
+	for i, count := range counts {
+		shards[i] = api.ShardStatus{Index: int32(i), AuthConfigs: count}
+	}
+	if !slices.Equal(authorinoInstance.Status.Shards, shards) {
+		authorinoInstance.Status.Shards = shards
+		return r.updateStatusConditions(authorinoInstance)
+	}
+
+	return nil
+}
+
+type watchedAuthConfigsKey struct{}
+
+// watchedAuthConfigs are the AuthConfigs watched by an Authorino instance, listed once over a reconciliation of the
+// instance
+type watchedAuthConfigs struct {
+	items  []unstructured.Unstructured
+	listed bool
+}
+
+// WithWatchedAuthConfigs returns a context in which the AuthConfigs watched by the Authorino instance are listed once,
+// and shared by the steps of the reconciliation that read them (sharding, permissions and status)
+func WithWatchedAuthConfigs(ctx context.Context) context.Context {
+	return context.WithValue(ctx, watchedAuthConfigsKey{}, &watchedAuthConfigs{})
+}
+
+// listWatchedAuthConfigs lists the AuthConfigs in the namespaces watched by the Authorino instance that match its
+// AuthConfig label selectors, narrowed to the shard of the instance if inShard.
+// The AuthConfigs are listed once per context (see WithWatchedAuthConfigs), so the changes made to them over the
+// reconciliation must be made to the items returned.
+func (r *AuthorinoReconciler) listWatchedAuthConfigs(ctx context.Context, authorinoInstance *api.Authorino, inShard bool) ([]unstructured.Unstructured, error) {
+	watched, _ := ctx.Value(watchedAuthConfigsKey{}).(*watchedAuthConfigs)
+	if watched == nil {
+		watched = &watchedAuthConfigs{}
+	}
+
+	if !watched.listed {
+		selector, err := labels.Parse(authorinoInstance.Spec.AuthConfigLabelSelectors)
+		if err != nil {
+			return nil, err
+		}
+
+		namespaces := WatchedNamespaces(authorinoInstance)
+		if namespaces == nil {
+			namespaces = []string{""} // cluster-wide
+		}
+
+		for _, namespace := range namespaces {
+			list := &unstructured.UnstructuredList{}
+			list.SetGroupVersionKind(authorinoResources.AuthConfigGVK.GroupVersion().WithKind(authorinoResources.AuthConfigGVK.Kind + "List"))
+			if err := r.Client.List(ctx, list, client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+				return nil, err
+			}
+			watched.items = append(watched.items, list.Items...)
+		}
+		watched.listed = true
+	}
+
+	if !inShard || authorinoInstance.Spec.Sharding == nil {
+		return watched.items, nil
+	}
+
+	selector, err := labels.Parse(AuthConfigLabelSelectors(authorinoInstance))
+	if err != nil {
+		return nil, err
+	}
+	var authConfigs []unstructured.Unstructured
+	for _, authConfig := range watched.items {
+		if selector.Matches(labels.Set(authConfig.GetLabels())) {
+			authConfigs = append(authConfigs, authConfig)
+		}
+	}
+	return authConfigs, nil
+}
@@ -33,7 +33,7 @@ func (r *AuthorinoReconciler) SetStatusFailed(reason string) statusUpdater {
 }
 
 func (r *AuthorinoReconciler) updateStatusConditions(authorino *api.Authorino, newConditions ...api.Condition) error {
-	newStatus := api.AuthorinoStatus{}
+	newStatus := *authorino.Status.DeepCopy()
 	newStatus.Conditions, _ = condition.AddOrUpdateStatusConditions(authorino.Status.Conditions, newConditions...)
 
 	patch := &api.Authorino{
@@ -48,7 +48,13 @@ func (r *AuthorinoReconciler) updateStatusConditions(authorino *api.Authorino, n
 		Status: newStatus,
 	}
 
-	return r.Client.Status().Patch(context.TODO(), patch, client.Apply, client.ForceOwnership, client.FieldOwner("authorino-operator"))
+	if err := r.Client.Status().Patch(context.TODO(), patch, client.Apply, client.ForceOwnership, client.FieldOwner(fieldOwner)); err != nil {
+		return err
+	}
+
+	// keep the in-memory status in sync, so later patches in the same reconciliation do not drop what was applied here
+	authorino.Status = newStatus
+	return nil
 }
 
 func statusReady() api.Condition {
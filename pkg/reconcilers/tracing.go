@@ -0,0 +1,111 @@
+package reconcilers
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	k8score "k8s.io/api/core/v1"
+
+	api "github.com/kuadrant/authorino-operator/api/v1beta1"
+	"github.com/kuadrant/authorino-operator/pkg/condition"
+)
+
+// schemes of the tracing endpoints supported by Authorino: rpc for OTLP over gRPC, http(s) for OTLP over HTTP
+const (
+	tracingSchemeRPC   = "rpc"
+	tracingSchemeHTTP  = "http"
+	tracingSchemeHTTPS = "https"
+
+	// default ports of the OTLP receivers
+	otlpGRPCPort = "4317"
+	otlpHTTPPort = "4318"
+)
+
+// ValidateTracingEndpoint parses the endpoint of the tracing collector, as expected by Authorino, i.e. an absolute URL
+// with the rpc, http or https scheme and a host. Settings that are valid but likely wrong are returned as warnings.
+func ValidateTracingEndpoint(tracing api.Tracing) ([]string, error) {
+	endpoint, err := url.Parse(tracing.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tracing endpoint %q: %v", tracing.Endpoint, err)
+	}
+
+	switch {
+	case endpoint.Scheme == "" || endpoint.Opaque != "": // e.g. host:port
+		return nil, fmt.Errorf("invalid tracing endpoint %q: missing scheme, use rpc:// (OTLP/gRPC) or http(s):// (OTLP/HTTP)", tracing.Endpoint)
+	case endpoint.Scheme != tracingSchemeRPC && endpoint.Scheme != tracingSchemeHTTP && endpoint.Scheme != tracingSchemeHTTPS:
+		return nil, fmt.Errorf("invalid tracing endpoint %q: unsupported scheme %s, use rpc:// (OTLP/gRPC) or http(s):// (OTLP/HTTP)", tracing.Endpoint, endpoint.Scheme)
+	}
+	if endpoint.Hostname() == "" {
+		return nil, fmt.Errorf("invalid tracing endpoint %q: missing host", tracing.Endpoint)
+	}
+
+	var warnings []string
+	switch port := endpoint.Port(); {
+	case endpoint.Scheme == tracingSchemeRPC && port == otlpHTTPPort:
+		warnings = append(warnings, fmt.Sprintf("rpc:// (OTLP/gRPC) endpoint with the port of OTLP/HTTP (%s)", otlpHTTPPort))
+	case endpoint.Scheme != tracingSchemeRPC && port == otlpGRPCPort:
+		warnings = append(warnings, fmt.Sprintf("%s:// (OTLP/HTTP) endpoint with the port of OTLP/gRPC (%s)", endpoint.Scheme, otlpGRPCPort))
+	}
+	if endpoint.Scheme == tracingSchemeRPC && strings.Trim(endpoint.Path, "/") != "" {
+		warnings = append(warnings, fmt.Sprintf("path %s of rpc:// (OTLP/gRPC) endpoint ignored", endpoint.Path))
+	}
+	if tracing.Insecure && endpoint.Scheme == tracingSchemeHTTPS {
+		warnings = append(warnings, "insecure connection to https:// endpoint")
+	}
+	if tracing.Insecure && tracing.Tls != nil {
+		warnings = append(warnings, "tls settings ignored with insecure connection")
+	}
+
+	return warnings, nil
+}
+
+// tracingEnabled tells whether Authorino is configured to export traces, i.e. the tracing endpoint is set and valid
+func tracingEnabled(authorino *api.Authorino) bool {
+	if authorino.Spec.Tracing.Endpoint == "" {
+		return false
+	}
+	_, err := ValidateTracingEndpoint(authorino.Spec.Tracing)
+	return err == nil
+}
+
+// ReconcileAuthorinoTracingStatus reports the tracing configuration of the Authorino instance in the TracingConfigured
+// condition, with a Warning event when the tracing endpoint is invalid, and thus left out of the Authorino Deployment,
+// or likely wrong
+func (r *AuthorinoReconciler) ReconcileAuthorinoTracingStatus(_ context.Context, authorinoInstance *api.Authorino) error {
+	tracing := api.Condition{
+		Type:   api.ConditionTracingConfigured,
+		Status: k8score.ConditionFalse,
+		Reason: statusTracingDisabled,
+	}
+
+	var warnings []string
+	if authorinoInstance.Spec.Tracing.Endpoint != "" {
+		var err error
+		warnings, err = ValidateTracingEndpoint(authorinoInstance.Spec.Tracing)
+		switch {
+		case err != nil:
+			tracing.Reason = statusInvalidTracingEndpoint
+			tracing.Message = fmt.Sprintf("%v, tracing disabled", err)
+		case len(warnings) > 0:
+			tracing.Status = k8score.ConditionTrue
+			tracing.Reason = statusTracingConfigured
+			tracing.Message = fmt.Sprintf("check the tracing settings: %s", strings.Join(warnings, "; "))
+		default:
+			tracing.Status = k8score.ConditionTrue
+			tracing.Reason = statusTracingConfigured
+		}
+	}
+
+	if _, changed := condition.AddOrUpdateStatusConditions(authorinoInstance.Status.Conditions, tracing); !changed {
+		return nil
+	}
+
+	if tracing.Message != "" && r.Recorder != nil {
+		r.Recorder.Eventf(authorinoInstance, nil, k8score.EventTypeWarning, tracing.Reason, "ValidateTracing",
+			"Tracing of the Authorino instance: %s", tracing.Message)
+	}
+
+	return r.updateStatusConditions(authorinoInstance, tracing)
+}
@@ -0,0 +1,23 @@
+package reconcilers
+
+import (
+	api "github.com/kuadrant/authorino-operator/api/v1beta1"
+	"github.com/kuadrant/authorino-operator/pkg/config"
+)
+
+// WatchScope returns the watch scope of the Authorino instance: ClusterWide or Namespaced
+func WatchScope(authorino *api.Authorino) string {
+	if authorino.Spec.ClusterWide {
+		return config.ScopeClusterWide
+	}
+	return config.ScopeNamespaced
+}
+
+// WatchedNamespaces returns the namespace watched by Authorino, i.e. the namespace of the Authorino instance, or nil
+// for cluster-wide scope
+func WatchedNamespaces(authorino *api.Authorino) []string {
+	if authorino.Spec.ClusterWide {
+		return nil
+	}
+	return []string{authorino.GetNamespace()}
+}
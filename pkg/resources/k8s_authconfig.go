@@ -0,0 +1,66 @@
+package resources
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// AuthConfigGVK is the GroupVersionKind of the Authorino AuthConfig API. AuthConfigs are owned by Authorino, not by the
+// operator, thus the objects are unstructured.
+var AuthConfigGVK = schema.GroupVersionKind{Group: "authorino.kuadrant.io", Version: "v1beta3", Kind: "AuthConfig"}
+
+// shardLabelPrefix is the prefix of the label that assigns an AuthConfig to a shard of a sharding group.
+// The name of the group (a DNS-1123 label) is the name segment of the label key.
+const shardLabelPrefix = "shard.operator.authorino.kuadrant.io/"
+
+// ShardLabel builds the key of the label that assigns AuthConfigs to the shards of a sharding group
+func ShardLabel(group string) string {
+	return shardLabelPrefix + group
+}
+
+// AuthConfigShard computes the shard an AuthConfig is assigned to, out of the given number of shards.
+// The assignment is a hash of the namespace and name of the AuthConfig, or of the namespace only if byNamespace is
+// true, so every Authorino instance of a group computes the same assignment.
+func AuthConfigShard(namespace, name string, shards int32, byNamespace bool) int32 {
+	if shards <= 1 {
+		return 0
+	}
+
+	key := namespace
+	if !byNamespace {
+		key = strings.Join([]string{namespace, name}, "/")
+	}
+
+	sum := sha256.Sum256([]byte(key))
+	return int32(binary.BigEndian.Uint32(sum[:4]) % uint32(shards))
+}
+
+// AuthConfigReady tells whether the Ready condition of the AuthConfig is true
+func AuthConfigReady(authConfig *unstructured.Unstructured) bool {
+	conditions, _, _ := unstructured.NestedSlice(authConfig.Object, "status", "conditions")
+	for _, c := range conditions {
+		cond, ok := c.(map[string]interface{})
+		if ok && cond["type"] == "Ready" {
+			return cond["status"] == "True"
+		}
+	}
+	return false
+}
+
+// AuthConfigUsesKubernetesAuth tells whether the AuthConfig authenticates with Kubernetes TokenReviews or authorizes with
+// Kubernetes SubjectAccessReviews, which Authorino must be allowed to create
+func AuthConfigUsesKubernetesAuth(authConfig *unstructured.Unstructured) bool {
+	for section, method := range map[string]string{"authentication": "kubernetesTokenReview", "authorization": "kubernetesSubjectAccessReview"} {
+		evaluators, _, _ := unstructured.NestedMap(authConfig.Object, "spec", section)
+		for _, e := range evaluators {
+			if evaluator, ok := e.(map[string]interface{}); ok && evaluator[method] != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package resources
+
+import (
+	"fmt"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestAuthConfigShard(t *testing.T) {
+	const shards = 4
+
+	counts := make([]int, shards)
+	for i := 0; i < 1000; i++ {
+		shard := AuthConfigShard(fmt.Sprintf("ns-%d", i%10), fmt.Sprintf("authconfig-%d", i), shards, false)
+		if shard < 0 || shard >= shards {
+			t.Fatalf("expected shard in [0, %d), got %d", shards, shard)
+		}
+		counts[shard]++
+	}
+	for shard, count := range counts {
+		if count < 150 {
+			t.Errorf("expected AuthConfigs evenly spread across shards, shard %d got %d out of 1000", shard, count)
+		}
+	}
+
+	if a, b := AuthConfigShard("ns", "a", shards, true), AuthConfigShard("ns", "b", shards, true); a != b {
+		t.Errorf("expected AuthConfigs of the same namespace in the same shard, got %d and %d", a, b)
+	}
+
+	if shard := AuthConfigShard("ns", "a", 1, false); shard != 0 {
+		t.Errorf("expected shard 0 with a single shard, got %d", shard)
+	}
+}
+
+func TestAuthConfigUsesKubernetesAuth(t *testing.T) {
+	authConfig := func(spec map[string]interface{}) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	}
+
+	for name, tc := range map[string]struct {
+		spec     map[string]interface{}
+		expected bool
+	}{
+		"token review": {
+			spec:     map[string]interface{}{"authentication": map[string]interface{}{"k8s": map[string]interface{}{"kubernetesTokenReview": map[string]interface{}{}}}},
+			expected: true,
+		},
+		"subject access review": {
+			spec:     map[string]interface{}{"authorization": map[string]interface{}{"k8s-rbac": map[string]interface{}{"kubernetesSubjectAccessReview": map[string]interface{}{"user": map[string]interface{}{"expression": "auth.identity.sub"}}}}},
+			expected: true,
+		},
+		"other methods": {
+			spec: map[string]interface{}{
+				"authentication": map[string]interface{}{"api-key": map[string]interface{}{"apiKey": map[string]interface{}{}}},
+				"authorization":  map[string]interface{}{"opa": map[string]interface{}{"opa": map[string]interface{}{}}},
+			},
+		},
+		"no spec": {},
+	} {
+		if got := AuthConfigUsesKubernetesAuth(authConfig(tc.spec)); got != tc.expected {
+			t.Errorf("%s: expected %v, got %v", name, tc.expected, got)
+		}
+	}
+}
@@ -34,6 +34,42 @@ func GetDeployment(name, namespace, saName string, replicas *int32, containers [
 	}
 }
 
+// GetTopologySpreadConstraints spreads the pods of the Authorino instance across zones and nodes, as evenly as possible
+// without making them unschedulable, e.g. in clusters whose nodes are not labeled with a zone
+func GetTopologySpreadConstraints(name string) []k8score.TopologySpreadConstraint {
+	var constraints []k8score.TopologySpreadConstraint
+	for _, topologyKey := range []string{k8score.LabelTopologyZone, k8score.LabelHostname} {
+		constraints = append(constraints, k8score.TopologySpreadConstraint{
+			MaxSkew:           1,
+			TopologyKey:       topologyKey,
+			WhenUnsatisfiable: k8score.ScheduleAnyway,
+			LabelSelector: &v1.LabelSelector{
+				MatchLabels: map[string]string{authorinoResourceLabel: name},
+			},
+		})
+	}
+	return constraints
+}
+
+// GetPodAntiAffinity prefers scheduling the pods of the Authorino instance onto different nodes
+func GetPodAntiAffinity(name string) *k8score.Affinity {
+	return &k8score.Affinity{
+		PodAntiAffinity: &k8score.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []k8score.WeightedPodAffinityTerm{
+				{
+					Weight: 100,
+					PodAffinityTerm: k8score.PodAffinityTerm{
+						TopologyKey: k8score.LabelHostname,
+						LabelSelector: &v1.LabelSelector{
+							MatchLabels: map[string]string{authorinoResourceLabel: name},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func GetContainer(image string, imagePullPolicy k8score.PullPolicy, containerName string, args []string, envVars []k8score.EnvVar, volMounts []k8score.VolumeMount) k8score.Container {
 	if imagePullPolicy == "" {
 		imagePullPolicy = k8score.PullAlways
@@ -0,0 +1,110 @@
+package resources
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	api "github.com/kuadrant/authorino-operator/api/v1beta1"
+)
+
+// Gateway API and Envoy Gateway kinds are not part of the operator's scheme, thus the objects are unstructured.
+var (
+	GRPCRouteGVK      = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "GRPCRoute"}
+	HTTPRouteGVK      = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "HTTPRoute"}
+	SecurityPolicyGVK = schema.GroupVersionKind{Group: "gateway.envoyproxy.io", Version: "v1alpha1", Kind: "SecurityPolicy"}
+)
+
+// NewAuthRoute builds a GRPCRoute or HTTPRoute (depending on the gvk) to the auth service
+func NewAuthRoute(gvk schema.GroupVersionKind, authorinoName, namespace string, port int32, parentRefs []api.GatewayAPIReference, hostnames []string, labels map[string]string) *unstructured.Unstructured {
+	var refs []interface{}
+	for _, parentRef := range parentRefs {
+		ref := gatewayAPIReference(parentRef)
+		if parentRef.Namespace != "" {
+			ref["namespace"] = parentRef.Namespace
+		}
+		refs = append(refs, ref)
+	}
+
+	spec := map[string]interface{}{
+		"parentRefs": refs,
+		"rules": []interface{}{
+			map[string]interface{}{
+				"backendRefs": []interface{}{authServiceBackendRef(authorinoName, port)},
+			},
+		},
+	}
+	if len(hostnames) > 0 {
+		var hosts []interface{}
+		for _, hostname := range hostnames {
+			hosts = append(hosts, hostname)
+		}
+		spec["hostnames"] = hosts
+	}
+
+	return newGatewayAPIObject(gvk, authorinoName, namespace, labels, spec)
+}
+
+// NewAuthSecurityPolicy builds an Envoy Gateway SecurityPolicy that configures the auth service as ext_authz backend
+func NewAuthSecurityPolicy(authorinoName, namespace string, grpcPort int32, targetRefs []api.GatewayAPIReference, labels map[string]string) *unstructured.Unstructured {
+	var refs []interface{}
+	for _, targetRef := range targetRefs {
+		refs = append(refs, gatewayAPIReference(targetRef))
+	}
+
+	spec := map[string]interface{}{
+		"targetRefs": refs,
+		"extAuth": map[string]interface{}{
+			"grpc": map[string]interface{}{
+				"backendRefs": []interface{}{authServiceBackendRef(authorinoName, grpcPort)},
+			},
+		},
+	}
+
+	return newGatewayAPIObject(SecurityPolicyGVK, authorinoName, namespace, labels, spec)
+}
+
+// GatewayAPIAncestorStatuses returns the status of each parent (routes) or ancestor (policies) of a Gateway API object
+func GatewayAPIAncestorStatuses(obj *unstructured.Unstructured) []interface{} {
+	if parents, found, _ := unstructured.NestedSlice(obj.Object, "status", "parents"); found {
+		return parents
+	}
+	ancestors, _, _ := unstructured.NestedSlice(obj.Object, "status", "ancestors")
+	return ancestors
+}
+
+func newGatewayAPIObject(gvk schema.GroupVersionKind, authorinoName, namespace string, labels map[string]string, spec map[string]interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetName(authorinoServiceName(authorinoName, authServiceName))
+	obj.SetNamespace(namespace)
+	obj.SetLabels(CopyMap(labels))
+	_ = unstructured.SetNestedMap(obj.Object, spec, "spec")
+	return obj
+}
+
+func gatewayAPIReference(ref api.GatewayAPIReference) map[string]interface{} {
+	group := ref.Group
+	if group == "" {
+		group = "gateway.networking.k8s.io"
+	}
+	kind := ref.Kind
+	if kind == "" {
+		kind = "Gateway"
+	}
+	r := map[string]interface{}{
+		"group": group,
+		"kind":  kind,
+		"name":  ref.Name,
+	}
+	if ref.SectionName != "" {
+		r["sectionName"] = ref.SectionName
+	}
+	return r
+}
+
+func authServiceBackendRef(authorinoName string, port int32) map[string]interface{} {
+	return map[string]interface{}{
+		"name": authorinoServiceName(authorinoName, authServiceName),
+		"port": int64(port),
+	}
+}
@@ -0,0 +1,106 @@
+package resources
+
+import (
+	k8snetworking "k8s.io/api/networking/v1"
+	k8smeta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// RouteGVK is the GroupVersionKind of the OpenShift Route API
+var RouteGVK = schema.GroupVersionKind{Group: "route.openshift.io", Version: "v1", Kind: "Route"}
+
+func NewOIDCIngress(authorinoName, namespace, host string, port int32, ingressClassName *string, tlsSecretName string, annotations, labels map[string]string) *k8snetworking.Ingress {
+	objMeta := getObjectMeta(namespace, authorinoServiceName(authorinoName, oidcServiceName), labels)
+	objMeta.Annotations = CopyMap(annotations)
+
+	pathType := k8snetworking.PathTypePrefix
+	ingress := &k8snetworking.Ingress{
+		TypeMeta:   k8smeta.TypeMeta{APIVersion: k8snetworking.SchemeGroupVersion.String(), Kind: "Ingress"},
+		ObjectMeta: objMeta,
+		Spec: k8snetworking.IngressSpec{
+			IngressClassName: ingressClassName,
+			Rules: []k8snetworking.IngressRule{
+				{
+					Host: host,
+					IngressRuleValue: k8snetworking.IngressRuleValue{
+						HTTP: &k8snetworking.HTTPIngressRuleValue{
+							Paths: []k8snetworking.HTTPIngressPath{
+								{
+									Path:     "/",
+									PathType: &pathType,
+									Backend: k8snetworking.IngressBackend{
+										Service: &k8snetworking.IngressServiceBackend{
+											Name: authorinoServiceName(authorinoName, oidcServiceName),
+											Port: k8snetworking.ServiceBackendPort{Number: port},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if tlsSecretName != "" {
+		ingress.Spec.TLS = []k8snetworking.IngressTLS{
+			{
+				Hosts:      []string{host},
+				SecretName: tlsSecretName,
+			},
+		}
+	}
+
+	return ingress
+}
+
+// NewOIDCRoute builds an OpenShift Route to the OIDC service. The Route API is not part of the operator's scheme,
+// thus the object is unstructured.
+// The destination CA certificate validates the certificate of the OIDC server with reencrypt termination.
+func NewOIDCRoute(authorinoName, namespace, host, termination, destinationCACertificate string, annotations, labels map[string]string) *unstructured.Unstructured {
+	spec := map[string]interface{}{
+		"to": map[string]interface{}{
+			"kind": "Service",
+			"name": authorinoServiceName(authorinoName, oidcServiceName),
+		},
+		"port": map[string]interface{}{
+			"targetPort": "http",
+		},
+		"tls": map[string]interface{}{
+			"termination":                   termination,
+			"insecureEdgeTerminationPolicy": "Redirect",
+		},
+	}
+	if host != "" {
+		spec["host"] = host
+	}
+	if destinationCACertificate != "" {
+		spec["tls"].(map[string]interface{})["destinationCACertificate"] = destinationCACertificate
+	}
+
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(RouteGVK)
+	route.SetName(authorinoServiceName(authorinoName, oidcServiceName))
+	route.SetNamespace(namespace)
+	route.SetLabels(CopyMap(labels))
+	route.SetAnnotations(CopyMap(annotations))
+	_ = unstructured.SetNestedMap(route.Object, spec, "spec")
+
+	return route
+}
+
+// RouteHost returns the host admitted for a Route, falling back to the one requested in the spec.
+func RouteHost(route *unstructured.Unstructured) string {
+	ingresses, _, _ := unstructured.NestedSlice(route.Object, "status", "ingress")
+	for _, i := range ingresses {
+		if ingress, ok := i.(map[string]interface{}); ok {
+			if host, ok := ingress["host"].(string); ok && host != "" {
+				return host
+			}
+		}
+	}
+	host, _, _ := unstructured.NestedString(route.Object, "spec", "host")
+	return host
+}
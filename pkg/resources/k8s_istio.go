@@ -0,0 +1,233 @@
+package resources
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+
+	yamlv3 "go.yaml.in/yaml/v3"
+)
+
+// AuthorizationPolicyGVK is the GroupVersionKind of the Istio AuthorizationPolicy API, which is not part of the
+// operator's scheme, thus the objects are unstructured
+var AuthorizationPolicyGVK = schema.GroupVersionKind{Group: "security.istio.io", Version: "v1", Kind: "AuthorizationPolicy"}
+
+// IstioMeshConfigKey is the key of the Istio mesh config in the mesh ConfigMap
+const IstioMeshConfigKey = "mesh"
+
+// IstioExtensionProviderName builds the name of the Istio extension provider of an Authorino instance.
+// The namespace cannot contain a ".", so the name is unambiguous between instances (see authorinoClusterRoleBindingName).
+func IstioExtensionProviderName(namespace, crName string) string {
+	return fmt.Sprintf("%s.%s-authorino", namespace, crName)
+}
+
+// NewIstioExtensionProvider builds the envoyExtAuthzGrpc extension provider of the Istio mesh config for an Authorino
+// instance
+func NewIstioExtensionProvider(authorinoName, namespace string, grpcPort int32) map[string]interface{} {
+	return map[string]interface{}{
+		"name": IstioExtensionProviderName(namespace, authorinoName),
+		"envoyExtAuthzGrpc": map[string]interface{}{
+			"service": fmt.Sprintf("%s.%s.svc.cluster.local", authorinoServiceName(authorinoName, authServiceName), namespace),
+			"port":    float64(grpcPort),
+		},
+	}
+}
+
+// UpsertIstioExtensionProvider adds the extension provider to the Istio mesh config, replacing the provider with the
+// same name only if it points at the same service, i.e. was registered for the same Authorino instance. It returns the
+// resulting mesh config and whether it was modified.
+func UpsertIstioExtensionProvider(meshConfig string, provider map[string]interface{}) (string, bool, error) {
+	mesh, providers, err := parseIstioExtensionProviders(meshConfig)
+	if err != nil {
+		return meshConfig, false, err
+	}
+
+	providerNode := &yamlv3.Node{}
+	if err := providerNode.Encode(provider); err != nil {
+		return meshConfig, false, err
+	}
+	// name first, as the providers are usually written
+	if c := providerNode.Content; len(c) > 2 && c[len(c)-2].Value == "name" {
+		c[0], c[1], c[len(c)-2], c[len(c)-1] = c[len(c)-2], c[len(c)-1], c[0], c[1]
+	}
+
+	found := false
+	for i, p := range providers {
+		existing, ok := p.(map[string]interface{})
+		if !ok || existing["name"] != provider["name"] {
+			continue
+		}
+		if istioExtensionProviderService(existing) != istioExtensionProviderService(provider) {
+			return meshConfig, false, fmt.Errorf("extension provider %s already registered for another service", provider["name"])
+		}
+		if reflect.DeepEqual(existing, provider) {
+			return meshConfig, false, nil
+		}
+		mesh.providers[i] = providerNode
+		found = true
+	}
+	if !found {
+		mesh.providers = append(mesh.providers, providerNode)
+	}
+
+	return mesh.marshal()
+}
+
+// RemoveIstioExtensionProvider removes the extension provider from the Istio mesh config, if registered with the same
+// name and pointing at the same service. It returns the resulting mesh config and whether it was modified.
+func RemoveIstioExtensionProvider(meshConfig string, provider map[string]interface{}) (string, bool, error) {
+	mesh, providers, err := parseIstioExtensionProviders(meshConfig)
+	if err != nil {
+		return meshConfig, false, err
+	}
+
+	var remaining []*yamlv3.Node
+	for i, p := range providers {
+		if existing, ok := p.(map[string]interface{}); ok && existing["name"] == provider["name"] && istioExtensionProviderService(existing) == istioExtensionProviderService(provider) {
+			continue
+		}
+		remaining = append(remaining, mesh.providers[i])
+	}
+	if len(remaining) == len(providers) {
+		return meshConfig, false, nil
+	}
+	mesh.providers = remaining
+
+	return mesh.marshal()
+}
+
+func istioExtensionProviderService(provider map[string]interface{}) string {
+	service, _, _ := unstructured.NestedString(provider, "envoyExtAuthzGrpc", "service")
+	return service
+}
+
+// NewIstioAuthorizationPolicy builds an AuthorizationPolicy with CUSTOM action that delegates the authorization of
+// the selected workloads to the extension provider
+func NewIstioAuthorizationPolicy(authorinoName, namespace, providerName string, selector, labels map[string]string) *unstructured.Unstructured {
+	spec := map[string]interface{}{
+		"action": "CUSTOM",
+		"provider": map[string]interface{}{
+			"name": providerName,
+		},
+		"rules": []interface{}{
+			map[string]interface{}{},
+		},
+	}
+	if len(selector) > 0 {
+		matchLabels := map[string]interface{}{}
+		for k, v := range selector {
+			matchLabels[k] = v
+		}
+		spec["selector"] = map[string]interface{}{
+			"matchLabels": matchLabels,
+		}
+	}
+
+	policy := &unstructured.Unstructured{}
+	policy.SetGroupVersionKind(AuthorizationPolicyGVK)
+	policy.SetName(authorinoServiceName(authorinoName, authServiceName))
+	policy.SetNamespace(namespace)
+	policy.SetLabels(CopyMap(labels))
+	_ = unstructured.SetNestedMap(policy.Object, spec, "spec")
+
+	return policy
+}
+
+// istioMeshConfig is the parsed Istio mesh config. The mesh config is owned by the user (or the Istio installer), so
+// the operator only ever rewrites its extensionProviders, keeping the order and comments of everything else. The
+// indentation of the whole mesh config is normalized though.
+type istioMeshConfig struct {
+	doc       *yamlv3.Node
+	providers []*yamlv3.Node
+}
+
+// parseIstioExtensionProviders parses the Istio mesh config, returning it along with its extension providers, decoded
+// as JSON (thus comparable to the providers built with NewIstioExtensionProvider), in the order of mesh.providers
+func parseIstioExtensionProviders(meshConfig string) (*istioMeshConfig, []interface{}, error) {
+	doc := &yamlv3.Node{}
+	if err := yamlv3.Unmarshal([]byte(meshConfig), doc); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse the Istio mesh config: %v", err)
+	}
+	if doc.Kind == 0 {
+		doc = &yamlv3.Node{Kind: yamlv3.DocumentNode, Content: []*yamlv3.Node{{Kind: yamlv3.MappingNode, Tag: "!!map"}}}
+	}
+	root := doc.Content[0]
+	if root.Kind == yamlv3.ScalarNode && root.Tag == "!!null" {
+		*root = yamlv3.Node{Kind: yamlv3.MappingNode, Tag: "!!map"}
+	}
+	if root.Kind != yamlv3.MappingNode {
+		return nil, nil, fmt.Errorf("failed to parse the Istio mesh config: not a map")
+	}
+
+	mesh := &istioMeshConfig{doc: doc}
+	if _, value := mesh.extensionProviders(); value != nil && value.Tag != "!!null" {
+		if value.Kind != yamlv3.SequenceNode {
+			return nil, nil, fmt.Errorf("failed to parse the Istio mesh config: extensionProviders is not a list")
+		}
+		mesh.providers = value.Content
+	}
+
+	providers := make([]interface{}, 0, len(mesh.providers))
+	for _, node := range mesh.providers {
+		out, err := yamlv3.Marshal(node)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse the Istio mesh config: %v", err)
+		}
+		var provider interface{}
+		if err := yaml.Unmarshal(out, &provider); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse the Istio mesh config: %v", err)
+		}
+		providers = append(providers, provider)
+	}
+
+	return mesh, providers, nil
+}
+
+// extensionProviders returns the key and value nodes of the extensionProviders of the mesh config, if any
+func (m *istioMeshConfig) extensionProviders() (*yamlv3.Node, *yamlv3.Node) {
+	root := m.doc.Content[0]
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == "extensionProviders" {
+			return root.Content[i], root.Content[i+1]
+		}
+	}
+	return nil, nil
+}
+
+// marshal sets the extension providers in the mesh config, removing extensionProviders when there are none left, and
+// serializes it
+func (m *istioMeshConfig) marshal() (string, bool, error) {
+	root := m.doc.Content[0]
+	root.Style &^= yamlv3.FlowStyle
+	key, value := m.extensionProviders()
+	switch {
+	case len(m.providers) == 0:
+		for i := 0; key != nil && i+1 < len(root.Content); i += 2 {
+			if root.Content[i] == key {
+				root.Content = append(root.Content[:i], root.Content[i+2:]...)
+				break
+			}
+		}
+	case key == nil:
+		root.Content = append(root.Content,
+			&yamlv3.Node{Kind: yamlv3.ScalarNode, Tag: "!!str", Value: "extensionProviders"},
+			&yamlv3.Node{Kind: yamlv3.SequenceNode, Tag: "!!seq", Content: m.providers})
+	default:
+		*value = yamlv3.Node{Kind: yamlv3.SequenceNode, Tag: "!!seq", Content: m.providers, HeadComment: value.HeadComment, LineComment: value.LineComment, FootComment: value.FootComment}
+	}
+
+	out := &bytes.Buffer{}
+	encoder := yamlv3.NewEncoder(out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(m.doc); err != nil {
+		return "", false, err
+	}
+	if err := encoder.Close(); err != nil {
+		return "", false, err
+	}
+	return out.String(), true, nil
+}
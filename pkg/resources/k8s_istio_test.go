@@ -0,0 +1,121 @@
+package resources
+
+import (
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/yaml"
+)
+
+func TestUpsertIstioExtensionProvider(t *testing.T) {
+	provider := NewIstioExtensionProvider("authorino", "ns", 50051)
+
+	t.Run("adds the provider preserving the rest of the mesh config", func(t *testing.T) {
+		meshConfig := "accessLogFile: /dev/stdout\nextensionProviders:\n- name: other\n  envoyOtelAls:\n    service: otel.istio-system.svc.cluster.local\n    port: 4317\n"
+
+		out, changed, err := UpsertIstioExtensionProvider(meshConfig, provider)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !changed {
+			t.Fatal("expected the mesh config to change")
+		}
+
+		mesh := map[string]interface{}{}
+		if err := yaml.Unmarshal([]byte(out), &mesh); err != nil {
+			t.Fatalf("failed to parse the resulting mesh config: %v", err)
+		}
+		if mesh["accessLogFile"] != "/dev/stdout" {
+			t.Errorf("expected accessLogFile to be preserved, got %v", mesh["accessLogFile"])
+		}
+		providers := mesh["extensionProviders"].([]interface{})
+		if len(providers) != 2 {
+			t.Fatalf("expected 2 extension providers, got %d", len(providers))
+		}
+		if !strings.Contains(out, "service: authorino-authorino-authorization.ns.svc.cluster.local") {
+			t.Errorf("expected the provider to point at the auth service, got:\n%s", out)
+		}
+	})
+
+	t.Run("keeps the comments and the order of the keys of the mesh config", func(t *testing.T) {
+		meshConfig := "# managed by the platform team\ntrustDomain: cluster.local\naccessLogFile: /dev/stdout # debug\nextensionProviders:\n- name: other\n  envoyOtelAls:\n    service: otel.istio-system.svc.cluster.local\n    port: 4317\n"
+
+		out, _, err := UpsertIstioExtensionProvider(meshConfig, provider)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.HasPrefix(out, "# managed by the platform team\ntrustDomain: cluster.local\naccessLogFile: /dev/stdout # debug\n") {
+			t.Errorf("expected the comments and the order of the keys to be kept, got:\n%s", out)
+		}
+
+		out, _, err = RemoveIstioExtensionProvider(out, provider)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.HasPrefix(out, "# managed by the platform team\ntrustDomain: cluster.local\naccessLogFile: /dev/stdout # debug\n") || !strings.Contains(out, "name: other") {
+			t.Errorf("expected the comments, the order of the keys and the other providers to be kept, got:\n%s", out)
+		}
+	})
+
+	t.Run("does not change the mesh config when already registered", func(t *testing.T) {
+		meshConfig, _, _ := UpsertIstioExtensionProvider("", provider)
+
+		_, changed, err := UpsertIstioExtensionProvider(meshConfig, provider)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if changed {
+			t.Error("expected the mesh config not to change")
+		}
+	})
+
+	t.Run("replaces the provider of the same instance", func(t *testing.T) {
+		meshConfig, _, _ := UpsertIstioExtensionProvider("", NewIstioExtensionProvider("authorino", "ns", 50001))
+
+		out, changed, err := UpsertIstioExtensionProvider(meshConfig, provider)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !changed || strings.Count(out, "name: ns.authorino-authorino") != 1 || !strings.Contains(out, "port: 50051") {
+			t.Errorf("expected the provider to be replaced, got:\n%s", out)
+		}
+	})
+
+	t.Run("refuses to replace a provider with the same name for another service", func(t *testing.T) {
+		meshConfig := "extensionProviders:\n- name: ns.authorino-authorino\n  envoyExtAuthzGrpc:\n    service: ext-authz.istio-system.svc.cluster.local\n    port: 9000\n"
+
+		out, changed, err := UpsertIstioExtensionProvider(meshConfig, provider)
+		if err == nil || !strings.Contains(err.Error(), "already registered") {
+			t.Errorf("expected error on a provider registered for another service, got %v", err)
+		}
+		if changed || out != meshConfig {
+			t.Errorf("expected the mesh config not to change, got:\n%s", out)
+		}
+	})
+}
+
+func TestRemoveIstioExtensionProvider(t *testing.T) {
+	provider := NewIstioExtensionProvider("authorino", "ns", 50051)
+	meshConfig, _, _ := UpsertIstioExtensionProvider("accessLogFile: /dev/stdout\n", provider)
+
+	out, changed, err := RemoveIstioExtensionProvider(meshConfig, provider)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !changed || strings.Contains(out, "extensionProviders") {
+		t.Errorf("expected the provider to be removed, got:\n%s", out)
+	}
+
+	_, changed, err = RemoveIstioExtensionProvider(out, provider)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if changed {
+		t.Error("expected the mesh config not to change when the provider is absent")
+	}
+
+	other := "extensionProviders:\n- name: ns.authorino-authorino\n  envoyExtAuthzGrpc:\n    service: ext-authz.istio-system.svc.cluster.local\n    port: 9000\n"
+	if _, changed, err = RemoveIstioExtensionProvider(other, provider); err != nil || changed {
+		t.Errorf("expected the provider with the same name for another service to be kept, got changed=%v, err: %v", changed, err)
+	}
+}
@@ -0,0 +1,27 @@
+package resources
+
+import (
+	policyv1 "k8s.io/api/policy/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// GetPodDisruptionBudget builds a PodDisruptionBudget that lets voluntary disruptions (e.g. node drains) evict one pod
+// of the Authorino instance at a time
+func GetPodDisruptionBudget(name, namespace string, labels map[string]string) *policyv1.PodDisruptionBudget {
+	maxUnavailable := intstr.FromInt32(1)
+
+	return &policyv1.PodDisruptionBudget{
+		TypeMeta: v1.TypeMeta{
+			APIVersion: policyv1.SchemeGroupVersion.String(),
+			Kind:       "PodDisruptionBudget",
+		},
+		ObjectMeta: getObjectMeta(namespace, name, labels),
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MaxUnavailable: &maxUnavailable,
+			Selector: &v1.LabelSelector{
+				MatchLabels: defaultAuthorinoLabels(name),
+			},
+		},
+	}
+}
@@ -33,6 +33,25 @@ func GetAuthorinoRoleBinding(namespace, crName, roleBindingNameSuffix, roleKind,
 	}
 }
 
+// GetAuthorinoRole builds a Role of an Authorino instance, named after the instance, with the given rules
+func GetAuthorinoRole(namespace, crName, roleNameSuffix string, rules []k8srbac.PolicyRule, labels map[string]string) *k8srbac.Role {
+	return &k8srbac.Role{
+		TypeMeta:   k8smeta.TypeMeta{APIVersion: k8srbac.SchemeGroupVersion.String(), Kind: "Role"},
+		ObjectMeta: getObjectMeta(namespace, authorinoRoleBindingName(crName, roleNameSuffix), labels),
+		Rules:      rules,
+	}
+}
+
+// GetAuthorinoClusterRole builds a ClusterRole of an Authorino instance with the given rules. As for the
+// ClusterRoleBindings, the name is qualified with the namespace of the Authorino instance.
+func GetAuthorinoClusterRole(namespace, crName, clusterRoleNameSuffix string, rules []k8srbac.PolicyRule, labels map[string]string) *k8srbac.ClusterRole {
+	return &k8srbac.ClusterRole{
+		TypeMeta:   k8smeta.TypeMeta{APIVersion: k8srbac.SchemeGroupVersion.String(), Kind: "ClusterRole"},
+		ObjectMeta: k8smeta.ObjectMeta{Name: authorinoClusterRoleBindingName(namespace, crName, clusterRoleNameSuffix), Labels: labels},
+		Rules:      rules,
+	}
+}
+
 func getRoleRefAndSubject(roleName, roleKind string, serviceAccount *k8score.ServiceAccount) (k8srbac.RoleRef, k8srbac.Subject) {
 	var roleRef = k8srbac.RoleRef{
 		Name: roleName,
@@ -9,6 +9,11 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+const (
+	authServiceName = "authorino-authorization"
+	oidcServiceName = "authorino-oidc"
+)
+
 func NewAuthService(authorinoName, serviceNamespace string, grpcPort, httpPort int32, labels map[string]string) *k8score.Service {
 	var ports []k8score.ServicePort
 	if grpcPort != 0 {
@@ -17,7 +22,7 @@ func NewAuthService(authorinoName, serviceNamespace string, grpcPort, httpPort i
 	if httpPort != 0 {
 		ports = append(ports, newServicePort("http", httpPort))
 	}
-	return newService("authorino-authorization", serviceNamespace, authorinoName, labels, ports...)
+	return newService(authServiceName, serviceNamespace, authorinoName, labels, ports...)
 }
 
 func NewOIDCService(authorinoName, authorinoNamespace string, port int32, labels map[string]string) *k8score.Service {
@@ -25,7 +30,7 @@ func NewOIDCService(authorinoName, authorinoNamespace string, port int32, labels
 	if port != 0 {
 		ports = append(ports, newServicePort("http", port))
 	}
-	return newService("authorino-oidc", authorinoNamespace, authorinoName, labels, ports...)
+	return newService(oidcServiceName, authorinoNamespace, authorinoName, labels, ports...)
 }
 
 func NewMetricsService(authorinoName, serviceNamespace string, port int32, labels map[string]string) *k8score.Service {
@@ -62,7 +67,7 @@ func EqualServices(s1, s2 *k8score.Service) bool {
 }
 
 func newService(serviceName, serviceNamespace, authorinoName string, labels map[string]string, servicePorts ...k8score.ServicePort) *k8score.Service {
-	objMeta := getObjectMeta(serviceNamespace, authorinoName+"-"+serviceName, labels)
+	objMeta := getObjectMeta(serviceNamespace, authorinoServiceName(authorinoName, serviceName), labels)
 	return &k8score.Service{
 		TypeMeta:   k8smeta.TypeMeta{APIVersion: k8score.SchemeGroupVersion.String(), Kind: "Service"},
 		ObjectMeta: objMeta,
@@ -86,3 +91,7 @@ func newServicePort(name string, number int32) k8score.ServicePort {
 	}
 	return port
 }
+
+func authorinoServiceName(authorinoName, serviceName string) string {
+	return authorinoName + "-" + serviceName
+}
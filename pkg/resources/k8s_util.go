@@ -12,10 +12,13 @@ func getObjectMeta(namespace, name string, labels map[string]string) v1.ObjectMe
 	return v1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels}
 }
 
+// label of the pods of an Authorino instance with the name of the instance
+const authorinoResourceLabel = "authorino-resource"
+
 func defaultAuthorinoLabels(name string) map[string]string {
 	return map[string]string{
-		"control-plane":      "controller-manager",
-		"authorino-resource": name,
+		"control-plane":        "controller-manager",
+		authorinoResourceLabel: name,
 	}
 }
 
@@ -0,0 +1,108 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	apimachineryruntime "k8s.io/apimachinery/pkg/runtime"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+
+	authorinooperatorv1beta1 "github.com/kuadrant/authorino-operator/api/v1beta1"
+	"github.com/kuadrant/authorino-operator/pkg/reconcilers"
+)
+
+// render prints the resources the operator reconciles for the Authorino CRs in a file, as YAML, without a cluster,
+// e.g. to diff the effect of a change to an Authorino CR in code review and CI.
+//
+//	manager render -f authorino.yaml [-assume-route-api]
+func render(args []string, out io.Writer) error {
+	var filename, namespace string
+	var routeAPIAvailable bool
+
+	flags := flag.NewFlagSet("render", flag.ContinueOnError)
+	flags.StringVar(&filename, "f", "", "Path to the file with the Authorino CRs to render, or - to read from the standard input")
+	flags.StringVar(&namespace, "namespace", "default", "Namespace of the Authorino CRs that do not set one")
+	flags.BoolVar(&routeAPIAvailable, "assume-route-api", false, "Render as if the OpenShift Route API were available, i.e. expose the OIDC server with a Route unless the CR sets the kind")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if filename == "" {
+		return fmt.Errorf("missing the file with the Authorino CRs to render (-f)")
+	}
+
+	in := os.Stdin
+	if filename != "-" {
+		file, err := os.Open(filename)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		in = file
+	}
+
+	decoder := utilyaml.NewYAMLOrJSONDecoder(in, 4096)
+	for {
+		authorino := &authorinooperatorv1beta1.Authorino{}
+		if err := decoder.Decode(authorino); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("failed to decode %s: %v", filename, err)
+		}
+		if authorino.Kind == "" && authorino.Name == "" {
+			continue // empty document
+		}
+		if gvk := authorino.GroupVersionKind(); gvk != authorinooperatorv1beta1.GroupVersion.WithKind("Authorino") {
+			return fmt.Errorf("unsupported kind %s in %s, only Authorino CRs can be rendered", gvk, filename)
+		}
+		if authorino.Namespace == "" {
+			authorino.Namespace = namespace
+		}
+
+		objs, err := reconcilers.RenderAuthorino(authorino, routeAPIAvailable)
+		if err != nil {
+			return err
+		}
+		for _, obj := range objs {
+			u, err := apimachineryruntime.DefaultUnstructuredConverter.ToUnstructured(obj)
+			if err != nil {
+				return err
+			}
+			// fields set by the cluster
+			unstructured.RemoveNestedField(u, "metadata", "creationTimestamp")
+			unstructured.RemoveNestedField(u, "status")
+
+			manifest, err := yaml.Marshal(u)
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintf(out, "---\n%s", manifest); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files of the render tests")
+
+// TestRender renders Authorino specs and compares the output with the golden files in testdata/render, updated with
+// `go test -run TestRender -update`
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		spec string
+	}{
+		{
+			name: "sample",
+			spec: `apiVersion: operator.authorino.kuadrant.io/v1beta1
+kind: Authorino
+metadata:
+  name: authorino-sample
+spec:
+  image: quay.io/kuadrant/authorino:v0.21.0
+  listener:
+    tls:
+      enabled: false
+  oidcServer:
+    tls:
+      enabled: false
+`,
+		},
+		{
+			name: "tls-replicas",
+			spec: `apiVersion: operator.authorino.kuadrant.io/v1beta1
+kind: Authorino
+metadata:
+  name: authorino
+  namespace: authorino
+  labels:
+    app: authorino
+spec:
+  image: quay.io/kuadrant/authorino:v0.21.0
+  replicas: 3
+  clusterWide: true
+  logLevel: debug
+  listener:
+    ports:
+      grpc: 50001
+      http: 5001
+    tls:
+      certSecretRef:
+        name: authorino-server-cert
+  oidcServer:
+    tls:
+      certSecretRef:
+        name: authorino-oidc-server-cert
+  metrics:
+    deep: true
+`,
+		},
+		{
+			name: "multiple",
+			spec: `apiVersion: operator.authorino.kuadrant.io/v1beta1
+kind: Authorino
+metadata:
+  name: authorino-a
+spec:
+  image: quay.io/kuadrant/authorino:latest
+  listener:
+    tls:
+      enabled: false
+  oidcServer:
+    tls:
+      enabled: false
+---
+---
+apiVersion: operator.authorino.kuadrant.io/v1beta1
+kind: Authorino
+metadata:
+  name: authorino-b
+  namespace: other
+spec:
+  image: quay.io/kuadrant/authorino:latest
+  managementState: Removed
+  listener:
+    tls:
+      enabled: false
+  oidcServer:
+    tls:
+      enabled: false
+`,
+		},
+		{
+			name: "integrations",
+			args: []string{"-assume-route-api"},
+			spec: `apiVersion: operator.authorino.kuadrant.io/v1beta1
+kind: Authorino
+metadata:
+  name: authorino
+  namespace: authorino
+spec:
+  image: quay.io/kuadrant/authorino:v0.21.0
+  listener:
+    tls:
+      enabled: false
+  oidcServer:
+    tls:
+      enabled: false
+    expose:
+      host: authorino-oidc.example.com
+  integration:
+    gatewayAPI:
+      kind: GRPCRoute
+      parentRefs:
+      - name: gateway
+        namespace: gateway-system
+    istio:
+      authorizationPolicy:
+        selector:
+          app: talker-api
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := filepath.Join(t.TempDir(), "authorino.yaml")
+			if err := os.WriteFile(input, []byte(tt.spec), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			out := &bytes.Buffer{}
+			if err := render(append([]string{"-f", input}, tt.args...), out); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			golden := filepath.Join("testdata", "render", tt.name+".yaml")
+			if *update {
+				if err := os.WriteFile(golden, out.Bytes(), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			expected, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("failed to read the golden file (run with -update to create it): %v", err)
+			}
+			if out.String() != string(expected) {
+				t.Errorf("rendered output differs from %s (run with -update to update it), got:\n%s", golden, out.String())
+			}
+		})
+	}
+}
+
+func TestRenderErrors(t *testing.T) {
+	input := filepath.Join(t.TempDir(), "authconfig.yaml")
+	if err := os.WriteFile(input, []byte("apiVersion: authorino.kuadrant.io/v1beta3\nkind: AuthConfig\nmetadata:\n  name: talker-api\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for args, expected := range map[string]string{
+		"":            "missing the file",
+		"-f " + input: "only Authorino CRs can be rendered",
+	} {
+		err := render(strings.Fields(args), &bytes.Buffer{})
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected error containing %q for args %q, got %v", expected, args, err)
+		}
+	}
+}